@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// exit codes returned by the non-interactive CLI
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitConflict = 4
+)
+
+// usageError is returned for a command invoked with missing or malformed flags
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, a ...any) error {
+	return &usageError{fmt.Sprintf(format, a...)}
+}
+
+const cliUsage = `Usage: folders <command> [flags]
+
+Commands:
+  list      --org ID
+            List the folders of an organization
+  children  --org ID --name NAME
+            List every folder below NAME
+  move      --src NAME --dst NAME [--org ID]
+            Move src to be a child of dst, with --org an empty dst moves src to the root
+  create    --org ID --name NAME [--parent NAME]
+            Create a folder, at the root when there is no parent
+  delete    --org ID --name NAME [--recursive]
+            Delete a folder, and its subtree with --recursive
+  rename    --org ID --name NAME --to NAME
+            Rename a folder
+  tree      [--org ID] [--name NAME]
+            Draw every folder, an organization or the subtree of NAME as a tree
+
+Every command also accepts:
+  --data FILE     existing JSON file to load folders from and save changes to, uses the sample data when empty
+  --format FORMAT output format: json, table or tree (default json, tree for the tree command)
+  --depth N       levels to draw in tree output, 0 draws every level
+  --mark NAMES    comma separated folders to mark in tree output
+
+Run without a command to start the interactive REPL.
+`
+
+// runs a single CLI command and returns the process exit code
+func runCLI(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, cliUsage)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, cliUsage)
+		return exitOK
+	}
+
+	cmd, ok := cliCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown command: %s\n\n%s", args[0], cliUsage)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	data := flags.String("data", "", "JSON file to load folders from and save changes to")
+	defaultFormat := "json"
+	if args[0] == "tree" {
+		defaultFormat = "tree"
+	}
+	format := flags.String("format", defaultFormat, "output format: json, table or tree")
+	depth := flags.Int("depth", 0, "levels to draw in tree output, 0 draws every level")
+	mark := flags.String("mark", "", "comma separated folders to mark in tree output")
+	run := cmd(flags)
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "Unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		return exitUsage
+	}
+
+	printer, ok := printers[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown format: %s\n", *format)
+		return exitUsage
+	}
+
+	driver, err := loadDriver(*data)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading folders: %s\n", err)
+		return exitCodeForError(err)
+	}
+
+	folders, err := run(driver)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return exitCodeForError(err)
+	}
+	treeOpts := folder.TreeOptions{MaxDepth: *depth}
+	if *mark != "" {
+		treeOpts.Marked = strings.Split(*mark, ",")
+	}
+	if err := printer(stdout, folders, treeOpts); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// an empty path serves the sample data without saving changes
+// a store starts out empty when its file is missing, which for a script is
+// far more likely a wrong path than a new file, so that is an error here
+func loadDriver(path string) (folder.IDriver, error) {
+	if path == "" {
+		return folder.NewDriver(folder.GetAllFolders()), nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return folder.NewStoredDriver(folder.NewJSONFileStore(path))
+}
+
+// a cliCommand registers its flags and returns the function that runs it
+// once they have been parsed
+type cliCommand func(flags *flag.FlagSet) func(driver folder.IDriver) ([]folder.Folder, error)
+
+var cliCommands = map[string]cliCommand{
+	"list": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			return driver.GetFoldersByOrgID(orgID), nil
+		}
+	},
+	"children": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID")
+		name := flags.String("name", "", "folder name")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			if *name == "" {
+				return nil, usageErrorf("--name is required")
+			}
+			folders, err := driver.GetDescendants(orgID, *name, 0)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", *name, err)
+			}
+			return folders, nil
+		}
+	},
+	"move": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID, resolves src and dst inside it")
+		src := flags.String("src", "", "folder to move")
+		dst := flags.String("dst", "", "new parent folder")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			if *src == "" {
+				return nil, usageErrorf("--src is required")
+			}
+			if *org == "" {
+				if *dst == "" {
+					return nil, usageErrorf("--dst is required without --org")
+				}
+				return driver.MoveFolder(*src, *dst)
+			}
+
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			var folders []folder.Folder
+			if *dst == "" {
+				folders, err = driver.MoveFolderToRoot(orgID, *src)
+			} else {
+				folders, err = driver.MoveFolderInOrg(orgID, *src, *dst)
+			}
+			return folder.FoldersInOrg(folders, orgID), err
+		}
+	},
+	"create": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID")
+		name := flags.String("name", "", "name of the new folder")
+		parent := flags.String("parent", "", "parent folder, the folder is created at the root when empty")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			folders, err := driver.CreateFolder(orgID, *name, *parent)
+			return folder.FoldersInOrg(folders, orgID), err
+		}
+	},
+	"delete": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID")
+		name := flags.String("name", "", "folder to delete")
+		recursive := flags.Bool("recursive", false, "also delete every folder below it")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			folders, err := driver.DeleteFolder(orgID, *name, *recursive)
+			return folder.FoldersInOrg(folders, orgID), err
+		}
+	},
+	"rename": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID")
+		name := flags.String("name", "", "folder to rename")
+		to := flags.String("to", "", "new name")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			folders, err := driver.RenameFolder(orgID, *name, *to)
+			return folder.FoldersInOrg(folders, orgID), err
+		}
+	},
+	"tree": func(flags *flag.FlagSet) func(folder.IDriver) ([]folder.Folder, error) {
+		org := flags.String("org", "", "organization ID, every organization is drawn when empty")
+		name := flags.String("name", "", "folder to draw the subtree of")
+		return func(driver folder.IDriver) ([]folder.Folder, error) {
+			if *org == "" {
+				if *name != "" {
+					return nil, usageErrorf("--name needs --org")
+				}
+				return driver.GetAllFolders(folder.OrderByPath), nil
+			}
+
+			orgID, err := parseOrgFlag(*org)
+			if err != nil {
+				return nil, err
+			}
+			if *name == "" {
+				return driver.GetFoldersByOrgID(orgID), nil
+			}
+			named, err := driver.GetFolder(orgID, *name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", *name, err)
+			}
+			children, err := driver.GetDescendants(orgID, *name, 0)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", *name, err)
+			}
+			return append([]folder.Folder{named}, children...), nil
+		}
+	},
+}
+
+func parseOrgFlag(org string) (uuid.UUID, error) {
+	if org == "" {
+		return uuid.Nil, usageErrorf("--org is required")
+	}
+	orgID, err := uuid.FromString(org)
+	if err != nil {
+		return uuid.Nil, usageErrorf("--org: %s", err)
+	}
+	return orgID, nil
+}
+
+func exitCodeForError(err error) int {
+	switch {
+	case errors.As(err, new(*usageError)),
+		errors.Is(err, folder.ErrInvalidFolderName):
+		return exitUsage
+	case errors.Is(err, folder.ErrSourceNotFound),
+		errors.Is(err, folder.ErrDestinationNotFound),
+		errors.Is(err, folder.ErrFolderNotFound),
+		errors.Is(err, folder.ErrParentNotFound),
+		errors.Is(err, fs.ErrNotExist):
+		return exitNotFound
+	case errors.Is(err, folder.ErrMoveToSelf),
+		errors.Is(err, folder.ErrMoveIntoDescendant),
+		errors.Is(err, folder.ErrCrossOrgMove),
+		errors.Is(err, folder.ErrSourceAmbiguous),
+		errors.Is(err, folder.ErrFolderAmbiguous),
+		errors.Is(err, folder.ErrFolderExists),
+		errors.Is(err, folder.ErrFolderHasChildren),
+		errors.Is(err, folder.ErrParentInOtherOrg),
+		errors.Is(err, folder.ErrNoFreeName):
+		return exitConflict
+	}
+	return exitError
+}
+
+// printers write command output, treeOpts only applies to tree output
+var printers = map[string]func(w io.Writer, folders []folder.Folder, treeOpts folder.TreeOptions) error{
+	"json":  printJSON,
+	"table": printTable,
+	"tree":  folder.RenderFolders,
+}
+
+func printJSON(w io.Writer, folders []folder.Folder, _ folder.TreeOptions) error {
+	if folders == nil {
+		folders = []folder.Folder{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(folders)
+}
+
+func printTable(w io.Writer, folders []folder.Folder, _ folder.TreeOptions) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "NAME\tORG ID\tPATH")
+	for _, f := range folder.SortFoldersByPath(folders) {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", f.Name, f.OrgId, f.Paths)
+	}
+	return table.Flush()
+}
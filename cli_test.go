@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+// writes folders to a JSON file in a temporary directory and returns its path
+func writeTestData(t *testing.T, folders []folder.Folder) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "folders.json")
+	if err := folder.NewJSONFileStore(path).Save(folders); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testCLIFolders() []folder.Folder {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	return []folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "echo", OrgId: secondOrgId, Paths: "echo"},
+	}
+}
+
+func Test_runCLI(t *testing.T) {
+	t.Parallel()
+	tests := [...]struct {
+		name      string
+		args      []string
+		wantCode  int
+		wantPaths []string
+	}{
+		{"list", []string{"list", "--org", FirstOrgID}, exitOK, []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "delta"}},
+		{"list unknown organization", []string{"list", "--org", uuid.Nil.String()}, exitOK, []string{}},
+		{"list without organization", []string{"list"}, exitUsage, nil},
+		{"list malformed organization", []string{"list", "--org", "not-a-uuid"}, exitUsage, nil},
+		{"children", []string{"children", "--org", FirstOrgID, "--name", "alpha"}, exitOK, []string{"alpha.bravo", "alpha.bravo.charlie"}},
+		{"children of leaf", []string{"children", "--org", FirstOrgID, "--name", "delta"}, exitOK, []string{}},
+		{"children of missing folder", []string{"children", "--org", FirstOrgID, "--name", "zulu"}, exitNotFound, nil},
+		{"move", []string{"move", "--src", "bravo", "--dst", "delta"}, exitOK, []string{"alpha", "delta", "delta.bravo", "delta.bravo.charlie", "echo"}},
+		{"move in organization", []string{"move", "--org", FirstOrgID, "--src", "bravo", "--dst", "delta"}, exitOK, []string{"alpha", "delta", "delta.bravo", "delta.bravo.charlie"}},
+		{"move to root", []string{"move", "--org", FirstOrgID, "--src", "bravo"}, exitOK, []string{"alpha", "bravo", "bravo.charlie", "delta"}},
+		{"move without destination", []string{"move", "--src", "bravo"}, exitUsage, nil},
+		{"move missing source", []string{"move", "--src", "zulu", "--dst", "delta"}, exitNotFound, nil},
+		{"move into descendant", []string{"move", "--src", "alpha", "--dst", "charlie"}, exitConflict, nil},
+		{"move across organizations", []string{"move", "--src", "alpha", "--dst", "echo"}, exitConflict, nil},
+		{"create", []string{"create", "--org", FirstOrgID, "--name", "foxtrot", "--parent", "delta"}, exitOK, []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "delta", "delta.foxtrot"}},
+		{"create duplicate", []string{"create", "--org", FirstOrgID, "--name", "bravo"}, exitConflict, nil},
+		{"create invalid name", []string{"create", "--org", FirstOrgID, "--name", "a.b"}, exitUsage, nil},
+		{"delete with children", []string{"delete", "--org", FirstOrgID, "--name", "alpha"}, exitConflict, nil},
+		{"delete recursive", []string{"delete", "--org", FirstOrgID, "--name", "alpha", "--recursive"}, exitOK, []string{"delta"}},
+		{"rename", []string{"rename", "--org", FirstOrgID, "--name", "alpha", "--to", "golf"}, exitOK, []string{"delta", "golf", "golf.bravo", "golf.bravo.charlie"}},
+		{"unknown command", []string{"frobnicate"}, exitUsage, nil},
+		{"unknown flag", []string{"list", "--bogus"}, exitUsage, nil},
+		{"unknown format", []string{"list", "--org", FirstOrgID, "--format", "xml"}, exitUsage, nil},
+		{"missing data file", []string{"list", "--org", FirstOrgID, "--data", "/nonexistent/dir/folders.json"}, exitNotFound, nil},
+		{"no command", []string{}, exitUsage, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := tt.args
+			if !slices.Contains(args, "--data") && len(args) > 0 {
+				args = append(slices.Clone(args), "--data", writeTestData(t, testCLIFolders()))
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := runCLI(args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d, stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantPaths == nil {
+				return
+			}
+
+			var got []folder.Folder
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("decoding output: %v\n%s", err, stdout.String())
+			}
+			gotPaths := []string{}
+			for _, f := range folder.SortFoldersByPath(got) {
+				gotPaths = append(gotPaths, f.Paths)
+			}
+			if diff := deep.Equal(gotPaths, tt.wantPaths); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_runCLI_SharedName(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	// bravo is used under both alpha and delta
+	data := writeTestData(t, []folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "delta.bravo"},
+	})
+
+	for _, command := range []string{"children", "tree"} {
+		var stdout, stderr bytes.Buffer
+		code := runCLI([]string{command, "--org", FirstOrgID, "--name", "bravo", "--data", data}, &stdout, &stderr)
+		if code != exitConflict {
+			t.Fatalf("%s exit code = %d, want %d, stderr: %s", command, code, exitConflict, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), folder.ErrFolderAmbiguous.Error()) {
+			t.Fatalf("%s stderr wanted to mention %q. got=%s", command, folder.ErrFolderAmbiguous, stderr.String())
+		}
+		if stdout.Len() != 0 {
+			t.Fatalf("%s printed folders for a shared name:\n%s", command, stdout.String())
+		}
+	}
+}
+
+func Test_runCLI_MissingDataFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "typo.json")
+
+	var stdout, stderr bytes.Buffer
+	code := runCLI([]string{"create", "--org", FirstOrgID, "--name", "alpha", "--data", path}, &stdout, &stderr)
+	if code != exitNotFound {
+		t.Fatalf("exit code = %d, want %d, stderr: %s", code, exitNotFound, stderr.String())
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("create on a missing data file wrote %s", path)
+	}
+}
+
+func Test_runCLI_SavesChanges(t *testing.T) {
+	t.Parallel()
+	path := writeTestData(t, testCLIFolders())
+
+	var stdout, stderr bytes.Buffer
+	if code := runCLI([]string{"move", "--src", "bravo", "--dst", "delta", "--data", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("move exit code = %d, stderr: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := runCLI([]string{"children", "--org", FirstOrgID, "--name", "delta", "--format", "table", "--data", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("children exit code = %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "delta.bravo.charlie") {
+		t.Errorf("move was not saved, got:\n%s", stdout.String())
+	}
+}
+
+func Test_runCLI_Tree(t *testing.T) {
+	t.Parallel()
+	tests := [...]struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{
+			"organization",
+			[]string{"tree", "--org", FirstOrgID},
+			exitOK,
+			"alpha\n└── bravo\n    └── charlie\ndelta\n",
+		},
+		{
+			"every organization",
+			[]string{"tree"},
+			exitOK,
+			"org " + SecondOrgID + "\necho\n\norg " + FirstOrgID + "\nalpha\n└── bravo\n    └── charlie\ndelta\n",
+		},
+		{
+			"subtree with marks",
+			[]string{"tree", "--org", FirstOrgID, "--name", "bravo", "--mark", "charlie"},
+			exitOK,
+			"bravo\n└── charlie *\n",
+		},
+		{
+			"max depth",
+			[]string{"tree", "--org", FirstOrgID, "--depth", "2"},
+			exitOK,
+			"alpha\n└── bravo\ndelta\n",
+		},
+		{
+			"tree format on another command",
+			[]string{"children", "--org", FirstOrgID, "--name", "alpha", "--format", "tree"},
+			exitOK,
+			"bravo\n└── charlie\n",
+		},
+		{
+			"name without organization",
+			[]string{"tree", "--name", "bravo"},
+			exitUsage,
+			"",
+		},
+		{
+			"missing folder",
+			[]string{"tree", "--org", FirstOrgID, "--name", "zulu"},
+			exitNotFound,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := append(slices.Clone(tt.args), "--data", writeTestData(t, testCLIFolders()))
+			var stdout, stderr bytes.Buffer
+			code := runCLI(args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d, stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", stdout.String(), tt.want)
+			}
+		})
+	}
+}
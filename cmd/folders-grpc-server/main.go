@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/georgechieng-sc/interns-2022/folderpb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	data := flag.String("data", "", "JSON file to load folders from and save changes to, serves the sample data when empty")
+	flag.Parse()
+
+	// the sample data is only found next to the source, so it isn't read
+	// unless it's going to be served
+	var driver folder.IDriver
+	if *data == "" {
+		driver = folder.NewDriver(folder.GetAllFolders())
+	} else {
+		var err error
+		driver, err = folder.NewStoredDriver(folder.NewJSONFileStore(*data))
+		if err != nil {
+			log.Fatalf("loading %s: %v", *data, err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	folderpb.RegisterFolderServiceServer(srv, newServer(driver))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// waits for in-flight RPCs to finish
+		srv.GracefulStop()
+	}()
+
+	log.Printf("listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("shut down")
+}
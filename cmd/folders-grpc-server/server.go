@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/georgechieng-sc/interns-2022/folderpb"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// how many folders GetAllChildFolders sends per stream message
+const childBatchSize = 500
+
+type server struct {
+	folderpb.UnimplementedFolderServiceServer
+	driver folder.IDriver
+}
+
+func newServer(driver folder.IDriver) *server {
+	return &server{driver: driver}
+}
+
+func (s *server) GetFoldersByOrgID(ctx context.Context, req *folderpb.GetFoldersByOrgIDRequest) (*folderpb.GetFoldersByOrgIDResponse, error) {
+	orgID, err := parseOrgID(req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &folderpb.GetFoldersByOrgIDResponse{
+		Folders: toProto(s.driver.GetFoldersByOrgID(orgID)),
+	}, nil
+}
+
+func (s *server) GetAllChildFolders(req *folderpb.GetAllChildFoldersRequest, stream folderpb.FolderService_GetAllChildFoldersServer) error {
+	orgID, err := parseOrgID(req.GetOrgId())
+	if err != nil {
+		return err
+	}
+
+	children, err := s.driver.GetDescendants(orgID, req.GetName(), 0)
+	if err != nil {
+		return status.Error(codeForError(err), err.Error())
+	}
+
+	for start := 0; start < len(children); start += childBatchSize {
+		end := min(start+childBatchSize, len(children))
+		err := stream.Send(&folderpb.GetAllChildFoldersResponse{
+			Folders: toProto(children[start:end]),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) MoveFolder(ctx context.Context, req *folderpb.MoveFolderRequest) (*folderpb.MoveFolderResponse, error) {
+	orgID, err := parseOrgID(req.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []folder.Folder
+	if req.GetDst() == "" {
+		folders, err = s.driver.MoveFolderToRoot(orgID, req.GetName())
+	} else {
+		folders, err = s.driver.MoveFolderInOrg(orgID, req.GetName(), req.GetDst())
+	}
+	if err != nil {
+		return nil, status.Error(codeForError(err), err.Error())
+	}
+
+	return &folderpb.MoveFolderResponse{Folders: toProto(folder.FoldersInOrg(folders, orgID))}, nil
+}
+
+// maps driver errors onto gRPC status codes
+func codeForError(err error) codes.Code {
+	switch {
+	case errors.Is(err, folder.ErrSourceNotFound),
+		errors.Is(err, folder.ErrDestinationNotFound),
+		errors.Is(err, folder.ErrFolderNotFound),
+		errors.Is(err, folder.ErrParentNotFound):
+		return codes.NotFound
+	case errors.Is(err, folder.ErrFolderExists):
+		return codes.AlreadyExists
+	case errors.Is(err, folder.ErrMoveToSelf),
+		errors.Is(err, folder.ErrMoveIntoDescendant),
+		errors.Is(err, folder.ErrCrossOrgMove),
+		errors.Is(err, folder.ErrSourceAmbiguous),
+		errors.Is(err, folder.ErrFolderAmbiguous),
+		errors.Is(err, folder.ErrFolderHasChildren):
+		return codes.FailedPrecondition
+	case errors.Is(err, folder.ErrInvalidFolderName):
+		return codes.InvalidArgument
+	}
+	return codes.Internal
+}
+
+func parseOrgID(orgID string) (uuid.UUID, error) {
+	parsed, err := uuid.FromString(orgID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return parsed, nil
+}
+
+func toProto(folders []folder.Folder) []*folderpb.Folder {
+	out := make([]*folderpb.Folder, len(folders))
+	for i, f := range folders {
+		out[i] = &folderpb.Folder{
+			Name:  f.Name,
+			OrgId: f.OrgId.String(),
+			Paths: f.Paths,
+		}
+	}
+	return out
+}
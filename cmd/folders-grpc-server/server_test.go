@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/georgechieng-sc/interns-2022/folderpb"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	FirstOrgID  = "c1556e17-b7c0-45a3-a6ae-9546248fb17a"
+	SecondOrgID = "38b9879b-f73b-4b0e-b9d9-4fc4c23643a7"
+)
+
+// starts the service on an in-memory listener and returns a client for it
+func newTestClient(t *testing.T, folders []folder.Folder) folderpb.FolderServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	folderpb.RegisterFolderServiceServer(srv, newServer(folder.NewDriver(folders)))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return folderpb.NewFolderServiceClient(conn)
+}
+
+func testFolders() []folder.Folder {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	return []folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "echo", OrgId: secondOrgId, Paths: "echo"},
+	}
+}
+
+// reads every batch off a GetAllChildFolders stream
+func receiveAll(stream folderpb.FolderService_GetAllChildFoldersClient) ([]*folderpb.Folder, int, error) {
+	var folders []*folderpb.Folder
+	var batches int
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return folders, batches, nil
+		}
+		if err != nil {
+			return nil, batches, err
+		}
+		folders = append(folders, res.GetFolders()...)
+		batches++
+	}
+}
+
+func Test_server_GetFoldersByOrgID(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, testFolders())
+
+	tests := [...]struct {
+		name     string
+		orgID    string
+		want     []string
+		wantCode codes.Code
+	}{
+		{"organization folders", FirstOrgID, []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "delta"}, codes.OK},
+		{"unknown organization", uuid.Nil.String(), nil, codes.OK},
+		{"malformed organization", "not-a-uuid", nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := client.GetFoldersByOrgID(context.Background(), &folderpb.GetFoldersByOrgIDRequest{OrgId: tt.orgID})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if diff := deep.Equal(sortedPaths(res.GetFolders()), sortedPaths(pathsOf(tt.want))); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_server_GetAllChildFolders(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, testFolders())
+
+	tests := [...]struct {
+		name     string
+		orgID    string
+		folder   string
+		want     []string
+		wantCode codes.Code
+	}{
+		{"subtree", FirstOrgID, "alpha", []string{"alpha.bravo", "alpha.bravo.charlie"}, codes.OK},
+		{"leaf", FirstOrgID, "delta", nil, codes.OK},
+		{"missing folder", FirstOrgID, "zulu", nil, codes.NotFound},
+		{"folder in another organization", FirstOrgID, "echo", nil, codes.NotFound},
+		{"malformed organization", "not-a-uuid", "alpha", nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.GetAllChildFolders(context.Background(), &folderpb.GetAllChildFoldersRequest{OrgId: tt.orgID, Name: tt.folder})
+			if err != nil {
+				t.Fatal(err)
+			}
+			folders, _, err := receiveAll(stream)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if diff := deep.Equal(sortedPaths(folders), sortedPaths(pathsOf(tt.want))); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_server_GetAllChildFolders_SharedName(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	// bravo is used under both alpha and delta
+	client := newTestClient(t, []folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "delta.bravo"},
+	})
+
+	stream, err := client.GetAllChildFolders(context.Background(), &folderpb.GetAllChildFoldersRequest{OrgId: FirstOrgID, Name: "bravo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = receiveAll(stream)
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Fatalf("code = %v, want %v (%v)", code, codes.FailedPrecondition, err)
+	}
+}
+
+func Test_server_GetAllChildFolders_Batches(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.FromStringOrNil(FirstOrgID)
+	count := childBatchSize*2 + 1
+	folders := []folder.Folder{{Name: "root", OrgId: orgId, Paths: "root"}}
+	for i := range count {
+		name := fmt.Sprintf("child-%d", i)
+		folders = append(folders, folder.Folder{Name: name, OrgId: orgId, Paths: "root." + name})
+	}
+	client := newTestClient(t, folders)
+
+	stream, err := client.GetAllChildFolders(context.Background(), &folderpb.GetAllChildFoldersRequest{OrgId: FirstOrgID, Name: "root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, batches, err := receiveAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != count {
+		t.Errorf("got %d folders, want %d", len(got), count)
+	}
+	if batches != 3 {
+		t.Errorf("got %d batches, want 3", batches)
+	}
+}
+
+func Test_server_MoveFolder(t *testing.T) {
+	t.Parallel()
+
+	tests := [...]struct {
+		name     string
+		orgID    string
+		folder   string
+		dst      string
+		want     []string
+		wantCode codes.Code
+	}{
+		{"move", FirstOrgID, "bravo", "delta", []string{"alpha", "delta", "delta.bravo", "delta.bravo.charlie"}, codes.OK},
+		{"move to root", FirstOrgID, "charlie", "", []string{"alpha", "alpha.bravo", "charlie", "delta"}, codes.OK},
+		{"missing source", FirstOrgID, "zulu", "delta", nil, codes.NotFound},
+		{"missing destination", FirstOrgID, "bravo", "zulu", nil, codes.NotFound},
+		{"into descendant", FirstOrgID, "alpha", "charlie", nil, codes.FailedPrecondition},
+		{"to self", FirstOrgID, "alpha", "alpha", nil, codes.FailedPrecondition},
+		{"destination in another organization", FirstOrgID, "alpha", "echo", nil, codes.NotFound},
+		{"malformed organization", "not-a-uuid", "alpha", "delta", nil, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, testFolders())
+			res, err := client.MoveFolder(context.Background(), &folderpb.MoveFolderRequest{OrgId: tt.orgID, Name: tt.folder, Dst: tt.dst})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if diff := deep.Equal(sortedPaths(res.GetFolders()), sortedPaths(pathsOf(tt.want))); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func pathsOf(paths []string) []*folderpb.Folder {
+	folders := make([]*folderpb.Folder, len(paths))
+	for i, path := range paths {
+		folders[i] = &folderpb.Folder{Paths: path}
+	}
+	return folders
+}
+
+// paths of folders in sorted order, empty for no folders
+func sortedPaths(folders []*folderpb.Folder) []string {
+	paths := make([]string, 0, len(folders))
+	for _, f := range folders {
+		paths = append(paths, f.GetPaths())
+	}
+	slices.Sort(paths)
+	return paths
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+)
+
+// how long in-flight requests get to finish once shutdown starts
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	data := flag.String("data", "", "JSON file to load folders from and save changes to, serves the sample data when empty")
+	flag.Parse()
+
+	// the sample data is only found next to the source, so it isn't read
+	// unless it's going to be served
+	var driver folder.IDriver
+	if *data == "" {
+		driver = folder.NewDriver(folder.GetAllFolders())
+	} else {
+		var err error
+		driver, err = folder.NewStoredDriver(folder.NewJSONFileStore(*data))
+		if err != nil {
+			log.Fatalf("loading %s: %v", *data, err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("listening on %s", *addr)
+	if err := serve(ctx, *addr, newServer(driver)); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("shut down")
+}
+
+// serves handler on addr until ctx is cancelled, then stops accepting
+// connections and waits for in-flight requests to finish
+func serve(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
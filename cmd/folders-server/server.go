@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// request body for the move endpoint, an empty Dst moves the folder to the
+// top level of its organization
+type moveRequest struct {
+	Dst string `json:"dst"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type server struct {
+	driver folder.IDriver
+}
+
+// routes the folder API onto driver
+func newServer(driver folder.IDriver) http.Handler {
+	s := &server{driver: driver}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/{orgID}/folders", s.handleGetFolders)
+	mux.HandleFunc("GET /orgs/{orgID}/folders/{name}/children", s.handleGetChildFolders)
+	mux.HandleFunc("GET /orgs/{orgID}/folders/{name}/ancestors", s.handleGetAncestors)
+	mux.HandleFunc("POST /orgs/{orgID}/folders/{name}/move", s.handleMoveFolder)
+	return mux
+}
+
+func (s *server) handleGetFolders(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	writeFolders(w, s.driver.GetFoldersByOrgID(orgID))
+}
+
+// the optional depth query parameter limits how many levels are returned,
+// depth=1 lists the direct children only
+func (s *server) handleGetChildFolders(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+
+	depth := 0
+	if param := r.URL.Query().Get("depth"); param != "" {
+		var err error
+		if depth, err = strconv.Atoi(param); err != nil || depth < 1 {
+			writeError(w, http.StatusBadRequest, errors.New("depth must be a positive integer"))
+			return
+		}
+	}
+
+	folders, err := s.driver.GetDescendants(orgID, name, depth)
+	if err != nil {
+		writeError(w, statusForError(err), err)
+		return
+	}
+	writeFolders(w, folders)
+}
+
+// lists the folders from the top level down to name, name included
+func (s *server) handleGetAncestors(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("name")
+
+	folders, err := s.driver.GetAncestors(orgID, name)
+	if err != nil {
+		writeError(w, statusForError(err), err)
+		return
+	}
+	writeFolders(w, folders)
+}
+
+func (s *server) handleMoveFolder(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := parseOrgID(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var folders []folder.Folder
+	var err error
+	if req.Dst == "" {
+		folders, err = s.driver.MoveFolderToRoot(orgID, name)
+	} else {
+		folders, err = s.driver.MoveFolderInOrg(orgID, name, req.Dst)
+	}
+	if err != nil {
+		writeError(w, statusForError(err), err)
+		return
+	}
+
+	writeFolders(w, folder.FoldersInOrg(folders, orgID))
+}
+
+// maps driver errors onto HTTP status codes
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, folder.ErrSourceNotFound),
+		errors.Is(err, folder.ErrDestinationNotFound),
+		errors.Is(err, folder.ErrFolderNotFound),
+		errors.Is(err, folder.ErrParentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, folder.ErrMoveToSelf),
+		errors.Is(err, folder.ErrMoveIntoDescendant),
+		errors.Is(err, folder.ErrCrossOrgMove),
+		errors.Is(err, folder.ErrSourceAmbiguous),
+		errors.Is(err, folder.ErrFolderAmbiguous),
+		errors.Is(err, folder.ErrFolderExists),
+		errors.Is(err, folder.ErrFolderHasChildren):
+		return http.StatusConflict
+	case errors.Is(err, folder.ErrInvalidFolderName):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func parseOrgID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	orgID, err := uuid.FromString(r.PathValue("orgID"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+// writes folders as a JSON array, never null
+func writeFolders(w http.ResponseWriter, folders []folder.Folder) {
+	if folders == nil {
+		folders = []folder.Folder{}
+	}
+	writeJSON(w, http.StatusOK, folders)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	FirstOrgID  = "c1556e17-b7c0-45a3-a6ae-9546248fb17a"
+	SecondOrgID = "38b9879b-f73b-4b0e-b9d9-4fc4c23643a7"
+)
+
+func Test_server(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "echo", OrgId: secondOrgId, Paths: "echo"},
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		want       []folder.Folder
+	}{
+		{
+			"list organization folders",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders",
+			"",
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+				{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+			},
+		},
+		{
+			"list unknown organization",
+			http.MethodGet,
+			"/orgs/" + uuid.Nil.String() + "/folders",
+			"",
+			http.StatusOK,
+			[]folder.Folder{},
+		},
+		{
+			"list malformed organization",
+			http.MethodGet,
+			"/orgs/not-a-uuid/folders",
+			"",
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"children",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/alpha/children",
+			"",
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+			},
+		},
+		{
+			"direct children",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/alpha/children?depth=1",
+			"",
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+			},
+		},
+		{
+			"children with invalid depth",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/alpha/children?depth=0",
+			"",
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"children of leaf",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/charlie/children",
+			"",
+			http.StatusOK,
+			[]folder.Folder{},
+		},
+		{
+			"children of folder in another organization",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/echo/children",
+			"",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"ancestors",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/charlie/ancestors",
+			"",
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+				{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: firstOrgId, Paths: "alpha.bravo.charlie"},
+			},
+		},
+		{
+			"ancestors of missing folder",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/echo/ancestors",
+			"",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"move",
+			http.MethodPost,
+			"/orgs/" + FirstOrgID + "/folders/bravo/move",
+			`{"dst": "delta"}`,
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+				{Name: "bravo", OrgId: firstOrgId, Paths: "delta.bravo"},
+				{Name: "charlie", OrgId: firstOrgId, Paths: "delta.bravo.charlie"},
+				{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+			},
+		},
+		{
+			"move to top level",
+			http.MethodPost,
+			"/orgs/" + FirstOrgID + "/folders/charlie/move",
+			`{"dst": ""}`,
+			http.StatusOK,
+			[]folder.Folder{
+				{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+				{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: firstOrgId, Paths: "charlie"},
+				{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+			},
+		},
+		{
+			"move into descendant",
+			http.MethodPost,
+			"/orgs/" + FirstOrgID + "/folders/alpha/move",
+			`{"dst": "charlie"}`,
+			http.StatusConflict,
+			nil,
+		},
+		{
+			"move to missing destination",
+			http.MethodPost,
+			"/orgs/" + FirstOrgID + "/folders/alpha/move",
+			`{"dst": "echo"}`,
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"move with malformed body",
+			http.MethodPost,
+			"/orgs/" + FirstOrgID + "/folders/alpha/move",
+			`{"dst": `,
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"move with wrong method",
+			http.MethodGet,
+			"/orgs/" + FirstOrgID + "/folders/alpha/move",
+			"",
+			http.StatusMethodNotAllowed,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newServer(folder.NewDriver(append([]folder.Folder{}, folders...)))
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s status wanted=%d. got=%d. body=%s",
+					tt.method, tt.path, tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var got []folder.Folder
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decoding response: %s", err)
+			}
+			folder.SortFoldersByPath(got)
+			folder.SortFoldersByPath(tt.want)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatalf("%s %s folders do not match expected:\n%s", tt.method, tt.path, diff[0])
+			}
+		})
+	}
+}
+
+func Test_server_ErrorBody(t *testing.T) {
+	t.Parallel()
+	handler := newServer(folder.NewDriver([]folder.Folder{}))
+	req := httptest.NewRequest(http.MethodPost, "/orgs/"+FirstOrgID+"/folders/alpha/move", strings.NewReader(`{"dst": "bravo"}`))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	var got errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding response: %s", err)
+	}
+	if !strings.Contains(got.Error, folder.ErrSourceNotFound.Error()) {
+		t.Fatalf("error body wanted to mention %q. got=%q", folder.ErrSourceNotFound, got.Error)
+	}
+	if rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("error Content-Type wanted=application/json. got=%s", rec.Header().Get("Content-Type"))
+	}
+}
+
+func Test_server_SharedNames(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	// bravo is used under both alpha and delta
+	handler := newServer(folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: firstOrgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "alpha.bravo"},
+		{Name: "delta", OrgId: firstOrgId, Paths: "delta"},
+		{Name: "bravo", OrgId: firstOrgId, Paths: "delta.bravo"},
+	}))
+
+	for _, path := range []string{
+		"/orgs/" + FirstOrgID + "/folders/bravo/children",
+		"/orgs/" + FirstOrgID + "/folders/bravo/children?depth=1",
+		"/orgs/" + FirstOrgID + "/folders/bravo/ancestors",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("GET %s status wanted=%d. got=%d. body=%s", path, http.StatusConflict, rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), folder.ErrFolderAmbiguous.Error()) {
+			t.Fatalf("GET %s error body wanted to mention %q. got=%s", path, folder.ErrFolderAmbiguous, rec.Body.String())
+		}
+	}
+}
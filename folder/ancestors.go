@@ -0,0 +1,83 @@
+package folder
+
+import (
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
+
+// returns the folders on the way from the top level folder down to Folder name,
+// name last, for breadcrumbs
+// runs in O(d) in the depth of name
+func (f *driver) GetAncestors(orgID uuid.UUID, name string) ([]Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := node.ancestors()
+	folders := make([]Folder, len(chain))
+	for i, ancestor := range chain {
+		folders[i] = *ancestor.folder
+	}
+	return folders, nil
+}
+
+// returns the folder directly above Folder name
+// a top level folder has no parent and returns ErrNoParent
+func (f *driver) GetParent(orgID uuid.UUID, name string) (Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return Folder{}, err
+	}
+	if node.parent == nil {
+		return Folder{}, ErrNoParent
+	}
+	return *node.parent.folder, nil
+}
+
+// returns the deepest folder above both Folder a and Folder b, which is a
+// itself when a is above b
+// folders under different top level folders return ErrNoCommonAncestor
+// runs in O(d) in the depth of the deeper folder
+func (f *driver) LowestCommonAncestor(orgID uuid.UUID, a string, b string) (Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	nodeA, err := f.find(orgID, a)
+	if err != nil {
+		return Folder{}, err
+	}
+	nodeB, err := f.find(orgID, b)
+	if err != nil {
+		return Folder{}, err
+	}
+
+	// both chains start at a top level folder, the last shared node is the
+	// answer
+	chainA, chainB := nodeA.ancestors(), nodeB.ancestors()
+	var common *FolderTreeNode
+	for i := 0; i < len(chainA) && i < len(chainB) && chainA[i] == chainB[i]; i++ {
+		common = chainA[i]
+	}
+	if common == nil {
+		return Folder{}, ErrNoCommonAncestor
+	}
+	return *common.folder, nil
+}
+
+// returns the nodes from the top level folder down to node, node included
+func (node *FolderTreeNode) ancestors() []*FolderTreeNode {
+	var chain []*FolderTreeNode
+	for curr := node; curr != nil; curr = curr.parent {
+		chain = append(chain, curr)
+	}
+	slices.Reverse(chain)
+	return chain
+}
@@ -0,0 +1,147 @@
+package folder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_GetAncestors(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"echo", secondOrgId, "echo"},
+	})
+
+	t.Parallel()
+	tests := [...]struct {
+		name      string
+		orgID     uuid.UUID
+		target    string
+		ancestors []folder.Folder
+		parent    folder.Folder
+		err       error
+	}{
+		{
+			"nested folder",
+			firstOrgId,
+			"charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			folder.Folder{"bravo", firstOrgId, "alpha.bravo"},
+			nil,
+		},
+		{
+			"child of top level folder",
+			firstOrgId,
+			"delta",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			folder.Folder{"alpha", firstOrgId, "alpha"},
+			nil,
+		},
+		{
+			"top level folder",
+			firstOrgId,
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			folder.Folder{},
+			folder.ErrNoParent,
+		},
+		{
+			"folder in another organization",
+			firstOrgId,
+			"echo",
+			nil,
+			folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+		{
+			"missing folder",
+			firstOrgId,
+			"invalid",
+			nil,
+			folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a top level folder has no parent but still has ancestors
+			ancestorsErr := tt.err
+			if errors.Is(ancestorsErr, folder.ErrNoParent) {
+				ancestorsErr = nil
+			}
+			ancestors, err := f.GetAncestors(tt.orgID, tt.target)
+			testFolderError(t, err, ancestorsErr)
+			if diff := deep.Equal(ancestors, tt.ancestors); diff != nil {
+				t.Fatalf("GetAncestors output does not match expected:\n%s", diff)
+			}
+
+			parent, err := f.GetParent(tt.orgID, tt.target)
+			testFolderError(t, err, tt.err)
+			if parent != tt.parent {
+				t.Fatalf("GetParent wanted=%v. got=%v", tt.parent, parent)
+			}
+		})
+	}
+}
+
+func Test_folder_LowestCommonAncestor(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "alpha.bravo.delta"},
+		{"echo", firstOrgId, "alpha.bravo.delta.echo"},
+		{"foxtrot", firstOrgId, "alpha.foxtrot"},
+		{"golf", firstOrgId, "golf"},
+		{"hotel", secondOrgId, "hotel"},
+	})
+
+	t.Parallel()
+	tests := [...]struct {
+		name string
+		a    string
+		b    string
+		want folder.Folder
+		err  error
+	}{
+		{"siblings", "charlie", "delta", folder.Folder{"bravo", firstOrgId, "alpha.bravo"}, nil},
+		{"different depths", "echo", "charlie", folder.Folder{"bravo", firstOrgId, "alpha.bravo"}, nil},
+		{"distant cousins", "echo", "foxtrot", folder.Folder{"alpha", firstOrgId, "alpha"}, nil},
+		{"ancestor of the other", "bravo", "echo", folder.Folder{"bravo", firstOrgId, "alpha.bravo"}, nil},
+		{"descendant of the other", "echo", "bravo", folder.Folder{"bravo", firstOrgId, "alpha.bravo"}, nil},
+		{"same folder", "delta", "delta", folder.Folder{"delta", firstOrgId, "alpha.bravo.delta"}, nil},
+		{"different top level folders", "charlie", "golf", folder.Folder{}, folder.ErrNoCommonAncestor},
+		{"folder in another organization", "charlie", "hotel", folder.Folder{}, folder.ErrFolderNotFound},
+		{"missing folder", "invalid", "charlie", folder.Folder{}, folder.ErrFolderNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.LowestCommonAncestor(firstOrgId, tt.a, tt.b)
+			testFolderError(t, err, tt.err)
+			if got != tt.want {
+				t.Fatalf("LowestCommonAncestor wanted=%v. got=%v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package folder
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+// top level bucket, holding one nested bucket of path -> Folder JSON per org
+var foldersBucket = []byte("folders")
+
+// BoltRepository is a Repository kept in a single bbolt database file.
+type BoltRepository struct {
+	db *bolt.DB
+}
+
+// opens or creates the database at path
+// fails after a second if another process holds the file
+func OpenBoltRepository(path string) (*BoltRepository, error) {
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(foldersBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltRepository{db: db}, nil
+}
+
+func (r *BoltRepository) LoadFolders() ([]Folder, error) {
+	folders := []Folder{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(foldersBucket).ForEachBucket(func(orgID []byte) error {
+			return tx.Bucket(foldersBucket).Bucket(orgID).ForEach(func(_, row []byte) error {
+				var folder Folder
+				if err := json.Unmarshal(row, &folder); err != nil {
+					return err
+				}
+				folders = append(folders, folder)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+func (r *BoltRepository) Commit(put []Folder, del []FolderKey) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(foldersBucket)
+
+		for _, key := range del {
+			org := root.Bucket(orgBucketKey(key.OrgID))
+			if org == nil {
+				continue
+			}
+			if err := org.Delete([]byte(key.Paths)); err != nil {
+				return err
+			}
+		}
+
+		for _, folder := range put {
+			org, err := root.CreateBucketIfNotExists(orgBucketKey(folder.OrgId))
+			if err != nil {
+				return err
+			}
+			row, err := json.Marshal(folder)
+			if err != nil {
+				return err
+			}
+			if err := org.Put([]byte(folder.Paths), row); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *BoltRepository) Close() error {
+	return r.db.Close()
+}
+
+func orgBucketKey(orgID uuid.UUID) []byte {
+	return []byte(orgID.String())
+}
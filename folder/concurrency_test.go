@@ -0,0 +1,155 @@
+package folder_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// checks every folder's path ends in its name and has a parent in the same org
+// reports with t.Errorf so it is safe to call from other goroutines
+func testFolderConsistency(t *testing.T, folders []folder.Folder) {
+	byPath := make(map[string]folder.Folder, len(folders))
+	for _, f := range folders {
+		byPath[f.OrgId.String()+":"+f.Paths] = f
+	}
+
+	for _, f := range folders {
+		paths := strings.Split(f.Paths, ".")
+		if paths[len(paths)-1] != f.Name {
+			t.Errorf("folder %s has inconsistent path %s", f.Name, f.Paths)
+			return
+		}
+		if len(paths) == 1 {
+			continue
+		}
+		parentPath := strings.Join(paths[:len(paths)-1], ".")
+		if _, found := byPath[f.OrgId.String()+":"+parentPath]; !found {
+			t.Errorf("folder %s has no parent at %s", f.Name, parentPath)
+			return
+		}
+	}
+}
+
+func Test_folder_Concurrent_MoveAndRead(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.charlie"},
+		{"delta", firstOrgId, "alpha.bravo.delta"},
+		{"echo", firstOrgId, "alpha.charlie.echo"},
+		{"alpha", secondOrgId, "alpha"},
+		{"bravo", secondOrgId, "alpha.bravo"},
+		{"charlie", secondOrgId, "alpha.charlie"},
+		{"delta", secondOrgId, "alpha.bravo.delta"},
+		{"echo", secondOrgId, "alpha.charlie.echo"},
+	})
+
+	const iterations = 200
+	var wg sync.WaitGroup
+
+	// writers bounce delta between bravo and charlie in both orgs
+	for _, orgID := range []uuid.UUID{firstOrgId, secondOrgId} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				dst := "bravo"
+				if i%2 == 0 {
+					dst = "charlie"
+				}
+				if _, err := f.MoveFolderInOrg(orgID, "delta", dst); err != nil {
+					t.Errorf("MoveFolderInOrg(delta, %s) returned error: %s", dst, err)
+					return
+				}
+			}
+		}()
+	}
+
+	// readers walk both orgs while the moves run
+	for _, orgID := range []uuid.UUID{firstOrgId, secondOrgId} {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if got := f.GetFoldersByOrgID(orgID); len(got) != 5 {
+					t.Errorf("GetFoldersByOrgID returned %d folders, want 5", len(got))
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if got := f.GetAllChildFolders(orgID, "alpha"); len(got) != 4 {
+					t.Errorf("GetAllChildFolders returned %d folders, want 4", len(got))
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	testFolderConsistency(t, append(f.GetFoldersByOrgID(firstOrgId), f.GetFoldersByOrgID(secondOrgId)...))
+}
+
+func Test_folder_Concurrent_Mutations(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"alpha", secondOrgId, "alpha"},
+	})
+
+	const workers = 4
+	const iterations = 50
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		for _, orgID := range []uuid.UUID{firstOrgId, secondOrgId} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					name := fmt.Sprintf("worker-%d-%d", w, i)
+					if _, err := f.CreateFolder(orgID, name, "alpha"); err != nil {
+						t.Errorf("CreateFolder(%s) returned error: %s", name, err)
+						return
+					}
+					if _, err := f.RenameFolder(orgID, name, name+"-renamed"); err != nil {
+						t.Errorf("RenameFolder(%s) returned error: %s", name, err)
+						return
+					}
+					// keep every other folder so moves and deletes both see a busy tree
+					if i%2 == 0 {
+						if _, err := f.DeleteFolder(orgID, name+"-renamed", false); err != nil {
+							t.Errorf("DeleteFolder(%s) returned error: %s", name, err)
+							return
+						}
+					}
+					testFolderConsistency(t, f.GetFoldersByOrgID(orgID))
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	testFolderConsistency(t, append(f.GetFoldersByOrgID(firstOrgId), f.GetFoldersByOrgID(secondOrgId)...))
+	for _, orgID := range []uuid.UUID{firstOrgId, secondOrgId} {
+		if got := f.GetAllChildFolders(orgID, "alpha"); len(got) != workers*iterations/2 {
+			t.Fatalf("GetAllChildFolders returned %d folders, want %d", len(got), workers*iterations/2)
+		}
+	}
+}
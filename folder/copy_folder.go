@@ -0,0 +1,108 @@
+package folder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// how many names CopyFolder asks a NameFunc for before giving up on a folder
+const MaxNameAttempts = 1000
+
+// NameFunc returns a candidate name for the copy of the folder called name.
+// attempt starts at 1 and is increased until the candidate is unused in the
+// organization.
+type NameFunc func(name string, attempt int) string
+
+// default NameFunc, copies are named name-copy, name-copy-2, name-copy-3...
+func SuffixNames(name string, attempt int) string {
+	if attempt == 1 {
+		return name + "-copy"
+	}
+	return fmt.Sprintf("%s-copy-%d", name, attempt)
+}
+
+// copies Folder name and its subtree in orgID under Folder dstParent
+// an empty dstParent places the copy at the top level of orgID
+// nothing is changed unless every folder in the subtree can be named
+// runs in O(k) in the size of the subtree rooted at name
+func (f *driver) CopyFolder(orgID uuid.UUID, name string, dstParent string, nameFn NameFunc) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if nameFn == nil {
+		nameFn = SuffixNames
+	}
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return []Folder{}, err
+	}
+
+	var parent *FolderTreeNode
+	if dstParent != "" {
+		if parent, err = f.find(orgID, dstParent); err != nil {
+			if errors.Is(err, ErrFolderNotFound) {
+				return []Folder{}, ErrParentNotFound
+			}
+			return []Folder{}, err
+		}
+	}
+
+	// pick every name before changing anything
+	// collectNodes visits parents before their children
+	subtree := node.collectNodes()
+	names := make(map[*FolderTreeNode]string, len(subtree))
+	taken := make(map[string]struct{}, len(subtree))
+	inUse := func(candidate string) bool {
+		_, picked := taken[candidate]
+		return f.nameInUse(orgID, candidate) || picked
+	}
+	for _, curr := range subtree {
+		copyName, err := freeName(curr.folder.Name, nameFn, inUse)
+		if err != nil {
+			return []Folder{}, err
+		}
+		names[curr] = copyName
+		taken[copyName] = struct{}{}
+	}
+
+	copies := make(map[*FolderTreeNode]*FolderTreeNode, len(subtree))
+	for _, curr := range subtree {
+		copyParent := parent
+		if curr != node {
+			copyParent = copies[curr.parent]
+		}
+		paths := names[curr]
+		if copyParent != nil {
+			paths = copyParent.folder.Paths + "." + paths
+		}
+
+		copyNode := NewFolderTreeNode(nil)
+		copyNode.folder = f.appendFolder(Folder{
+			Name:  names[curr],
+			OrgId: orgID,
+			Paths: paths,
+		})
+		f.index(copyNode)
+		f.attach(copyNode, copyParent)
+		copies[curr] = copyNode
+	}
+
+	return f.allFolders(), nil
+}
+
+// asks nameFn for names until one is valid and not inUse
+func freeName(name string, nameFn NameFunc, inUse func(string) bool) (string, error) {
+	for attempt := 1; attempt <= MaxNameAttempts; attempt++ {
+		candidate := nameFn(name, attempt)
+		if !validFolderName(candidate) {
+			return "", ErrInvalidFolderName
+		}
+		if !inUse(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", ErrNoFreeName
+}
@@ -0,0 +1,210 @@
+package folder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_CopyFolder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	templateNames := func(name string, attempt int) string {
+		return strings.TrimPrefix(name, "template-")
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name      string
+		orgID     uuid.UUID
+		target    string
+		dstParent string
+		nameFn    folder.NameFunc
+		folders   []folder.Folder
+		want      []folder.Folder
+		err       error
+	}{
+		{
+			"copy leaf next to itself",
+			firstOrgId,
+			"bravo",
+			"alpha",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"bravo-copy", firstOrgId, "alpha.bravo-copy"},
+			},
+			nil,
+		},
+		{
+			"copy subtree to top level",
+			firstOrgId,
+			"bravo",
+			"",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.delta"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.delta"},
+				{"bravo-copy", firstOrgId, "bravo-copy"},
+				{"charlie-copy", firstOrgId, "bravo-copy.charlie-copy"},
+				{"delta-copy", firstOrgId, "bravo-copy.delta-copy"},
+			},
+			nil,
+		},
+		{
+			"copy skips names already in use",
+			firstOrgId,
+			"alpha",
+			"",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha-copy", firstOrgId, "alpha-copy"},
+				{"alpha-copy-2", firstOrgId, "alpha-copy-2"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha-copy", firstOrgId, "alpha-copy"},
+				{"alpha-copy-2", firstOrgId, "alpha-copy-2"},
+				{"alpha-copy-3", firstOrgId, "alpha-copy-3"},
+			},
+			nil,
+		},
+		{
+			"copy into own subtree",
+			firstOrgId,
+			"alpha",
+			"bravo",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha-copy", firstOrgId, "alpha.bravo.alpha-copy"},
+				{"bravo-copy", firstOrgId, "alpha.bravo.alpha-copy.bravo-copy"},
+			},
+			nil,
+		},
+		{
+			"copy template with custom names",
+			firstOrgId,
+			"template-project",
+			"projects",
+			templateNames,
+			[]folder.Folder{
+				{"projects", firstOrgId, "projects"},
+				{"template-project", firstOrgId, "template-project"},
+				{"template-docs", firstOrgId, "template-project.template-docs"},
+				{"template-src", firstOrgId, "template-project.template-src"},
+			},
+			[]folder.Folder{
+				{"projects", firstOrgId, "projects"},
+				{"template-project", firstOrgId, "template-project"},
+				{"template-docs", firstOrgId, "template-project.template-docs"},
+				{"template-src", firstOrgId, "template-project.template-src"},
+				{"project", firstOrgId, "projects.project"},
+				{"docs", firstOrgId, "projects.project.docs"},
+				{"src", firstOrgId, "projects.project.src"},
+			},
+			nil,
+		},
+		{
+			"attempt copy when name function never finds a free name",
+			firstOrgId,
+			"alpha",
+			"",
+			func(name string, attempt int) string { return name },
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrNoFreeName,
+		},
+		{
+			"attempt copy with invalid generated name",
+			firstOrgId,
+			"alpha",
+			"",
+			func(name string, attempt int) string { return name + ".copy" },
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrInvalidFolderName,
+		},
+		{
+			"attempt copy folder in another organization",
+			secondOrgId,
+			"alpha",
+			"",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+		{
+			"attempt copy to non-existant parent",
+			firstOrgId,
+			"alpha",
+			"invalid",
+			nil,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrParentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.CopyFolder(tt.orgID, tt.target, tt.dstParent, tt.nameFn)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_CopyFolder_IsIndependent(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "charlie"},
+	})
+
+	_, err := f.CopyFolder(firstOrgId, "alpha", "", nil)
+	testFolderError(t, err, nil)
+
+	// changes to the original must not show up in the copy
+	_, err = f.MoveFolder("bravo", "charlie")
+	testFolderError(t, err, nil)
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "alpha"), nil)
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "alpha-copy"), []folder.Folder{
+		{"bravo-copy", firstOrgId, "alpha-copy.bravo-copy"},
+	})
+}
@@ -0,0 +1,55 @@
+package folder
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// creates Folder name in orgID as a child of Folder parentName
+// an empty parentName creates a new top level folder
+// runs in O(1) unless the backing slice has to grow
+func (f *driver) CreateFolder(orgID uuid.UUID, name string, parentName string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !validFolderName(name) {
+		return []Folder{}, ErrInvalidFolderName
+	}
+	if f.nameInUse(orgID, name) {
+		return []Folder{}, ErrFolderExists
+	}
+
+	paths := name
+	var parent *FolderTreeNode
+	if parentName != "" {
+		var err error
+		if parent, err = f.find(orgID, parentName); errors.Is(err, ErrFolderAmbiguous) {
+			return []Folder{}, err
+		} else if err != nil {
+			if f.onlyInOtherOrg(orgID, parentName) {
+				return []Folder{}, ErrParentInOtherOrg
+			}
+			return []Folder{}, ErrParentNotFound
+		}
+		paths = parent.folder.Paths + "." + name
+	}
+
+	node := NewFolderTreeNode(nil)
+	f.addOrg(orgID)
+	node.folder = f.appendFolder(Folder{
+		Name:  name,
+		OrgId: orgID,
+		Paths: paths,
+	})
+	f.index(node)
+	f.attach(node, parent)
+
+	return f.allFolders(), nil
+}
+
+// names are used as path segments so they cannot contain the separator
+func validFolderName(name string) bool {
+	return name != "" && !strings.Contains(name, ".")
+}
@@ -0,0 +1,185 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_CreateFolder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name       string
+		orgID      uuid.UUID
+		folderName string
+		parent     string
+		folders    []folder.Folder
+		want       []folder.Folder
+		err        error
+	}{
+		{
+			"create top-level in empty driver",
+			firstOrgId,
+			"alpha",
+			"",
+			[]folder.Folder{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"create child of top-level",
+			firstOrgId,
+			"bravo",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			nil,
+		},
+		{
+			"create deep child",
+			firstOrgId,
+			"delta",
+			"charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+			},
+			nil,
+		},
+		{
+			"create name used by another organization",
+			secondOrgId,
+			"alpha",
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"attempt create duplicate name",
+			firstOrgId,
+			"bravo",
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderExists,
+		},
+		{
+			"attempt create under missing parent",
+			firstOrgId,
+			"bravo",
+			"invalid",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrParentNotFound,
+		},
+		{
+			"attempt create under parent in another organization",
+			firstOrgId,
+			"charlie",
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrParentInOtherOrg,
+		},
+		{
+			"attempt create name containing separator",
+			firstOrgId,
+			"bravo.charlie",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrInvalidFolderName,
+		},
+		{
+			"attempt create empty name",
+			firstOrgId,
+			"",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrInvalidFolderName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.CreateFolder(tt.orgID, tt.folderName, tt.parent)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_CreateFolder_ThenMove(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+	})
+
+	// enough creations to force the backing slice to grow several times
+	names := []string{"bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"}
+	parent := "alpha"
+	for _, name := range names {
+		if _, err := f.CreateFolder(firstOrgId, name, parent); err != nil {
+			t.Fatalf("CreateFolder(%s, %s) returned error: %s", name, parent, err)
+		}
+		parent = name
+	}
+
+	got, err := f.MoveFolder("echo", "alpha")
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+		{"echo", firstOrgId, "alpha.echo"},
+		{"foxtrot", firstOrgId, "alpha.echo.foxtrot"},
+		{"golf", firstOrgId, "alpha.echo.foxtrot.golf"},
+		{"hotel", firstOrgId, "alpha.echo.foxtrot.golf.hotel"},
+	})
+
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "foxtrot"), []folder.Folder{
+		{"golf", firstOrgId, "alpha.echo.foxtrot.golf"},
+		{"hotel", firstOrgId, "alpha.echo.foxtrot.golf.hotel"},
+	})
+}
@@ -0,0 +1,35 @@
+package folder
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// deletes Folder name from orgID
+// when recursive is set the whole subtree rooted at name is deleted, otherwise
+// name must not have any children
+// runs in O(n) in length of folders due to compacting the backing slice
+func (f *driver) DeleteFolder(orgID uuid.UUID, name string, recursive bool) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return []Folder{}, err
+	}
+	if !recursive && len(node.children) > 0 {
+		return []Folder{}, ErrFolderHasChildren
+	}
+
+	f.detach(node)
+
+	// unregister the subtree
+	removed := make(map[*Folder]struct{})
+	for _, curr := range node.collectNodes() {
+		f.unindex(curr)
+		removed[curr.folder] = struct{}{}
+	}
+
+	f.removeFolders(removed)
+
+	return f.allFolders(), nil
+}
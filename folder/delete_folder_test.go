@@ -0,0 +1,177 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_DeleteFolder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name       string
+		orgID      uuid.UUID
+		folderName string
+		recursive  bool
+		folders    []folder.Folder
+		want       []folder.Folder
+		err        error
+	}{
+		{
+			"delete only folder",
+			firstOrgId,
+			"alpha",
+			false,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			nil,
+		},
+		{
+			"delete leaf",
+			firstOrgId,
+			"charlie",
+			false,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.charlie"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			nil,
+		},
+		{
+			"delete subtree",
+			firstOrgId,
+			"bravo",
+			true,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+				{"echo", firstOrgId, "alpha.echo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"echo", firstOrgId, "alpha.echo"},
+			},
+			nil,
+		},
+		{
+			"delete top-level subtree",
+			firstOrgId,
+			"alpha",
+			true,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "charlie"},
+			},
+			[]folder.Folder{
+				{"charlie", firstOrgId, "charlie"},
+			},
+			nil,
+		},
+		{
+			"delete only touches the requested organization",
+			secondOrgId,
+			"alpha",
+			true,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+				{"bravo", secondOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			nil,
+		},
+		{
+			"attempt non-recursive delete with children",
+			firstOrgId,
+			"alpha",
+			false,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderHasChildren,
+		},
+		{
+			"attempt delete non-existant folder",
+			firstOrgId,
+			"invalid",
+			true,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+		{
+			"attempt delete folder in another organization",
+			secondOrgId,
+			"alpha",
+			true,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.DeleteFolder(tt.orgID, tt.folderName, tt.recursive)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_DeleteFolder_ThenMove(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "delta"},
+		{"echo", firstOrgId, "delta.echo"},
+	})
+
+	_, err := f.DeleteFolder(firstOrgId, "bravo", true)
+	testFolderError(t, err, nil)
+
+	// remaining nodes must still point at the right folders after compaction
+	got, err := f.MoveFolder("delta", "alpha")
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"echo", firstOrgId, "alpha.delta.echo"},
+	})
+
+	_, err = f.CreateFolder(firstOrgId, "bravo", "echo")
+	testFolderError(t, err, nil)
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "delta"), []folder.Folder{
+		{"echo", firstOrgId, "alpha.delta.echo"},
+		{"bravo", firstOrgId, "alpha.delta.echo.bravo"},
+	})
+}
@@ -0,0 +1,60 @@
+package folder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// errors returned by the IDriver mutation methods, compare with errors.Is
+var (
+	ErrFolderNotFound    = errors.New("Folder does not exist in the organization")
+	ErrFolderAmbiguous   = errors.New("Folder name is shared by more than one folder in the organization, address it by path")
+	ErrFolderHasChildren = errors.New("Folder still has child folders")
+	ErrInvalidFolderName = errors.New("Folder name must be non-empty and cannot contain '.'")
+	ErrFolderExists      = errors.New("Folder already exists in the organization")
+	ErrParentNotFound    = errors.New("Parent folder does not exist")
+	ErrParentInOtherOrg  = errors.New("Parent folder belongs to a different organization")
+	ErrNoFreeName        = errors.New("Could not find an unused folder name")
+)
+
+// errors returned by the paginated read methods
+var (
+	ErrInvalidPageSize   = errors.New("Page size must be at least 1")
+	ErrInvalidCursor     = errors.New("Cursor is malformed or belongs to a different listing")
+	ErrCursorInvalidated = errors.New("Folders changed since the cursor was issued, restart the listing")
+)
+
+// errors returned by the ancestor queries
+var (
+	ErrNoParent         = errors.New("Folder is a top level folder and has no parent")
+	ErrNoCommonAncestor = errors.New("Folders are in different top level trees")
+)
+
+// errors wrapped by MoveError for MoveFolder and MoveFolderInOrg
+var (
+	ErrMoveToSelf          = errors.New("Cannot move a folder to itself")
+	ErrSourceNotFound      = errors.New("Source folder does not exist")
+	ErrSourceAmbiguous     = errors.New("Source folder name is used by more than one folder")
+	ErrDestinationNotFound = errors.New("Destination folder does not exist")
+	ErrCrossOrgMove        = errors.New("Cannot move a folder to a different organization")
+	ErrMoveIntoDescendant  = errors.New("Cannot move a folder to a child of itself")
+)
+
+// MoveError describes a rejected move. OrgID is the organization the move was
+// resolved in, and is uuid.Nil when the source could not be resolved.
+type MoveError struct {
+	Src   string
+	Dst   string
+	OrgID uuid.UUID
+	Err   error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("move %s to %s: %s", e.Src, e.Dst, e.Err)
+}
+
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
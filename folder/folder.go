@@ -1,30 +1,117 @@
 package folder
 
 import (
+	"io"
+	"maps"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gofrs/uuid"
 )
 
+// Read methods that don't take a FolderOrder return folders in pre-order, each
+// folder followed by its subtree, with top level folders and siblings sorted by
+// name. Mutations return every folder in storage order.
+// Folders under different parents can share a name. Methods taking a name only
+// find folders whose name is unique in the organization and report a shared
+// name with ErrFolderAmbiguous where they return errors, the ByPath methods
+// reach every folder.
 type IDriver interface {
 	// GetFoldersByOrgID returns all folders that belong to a specific orgID.
 	GetFoldersByOrgID(orgID uuid.UUID) []Folder
+	// GetFolder returns the folder called name in orgID.
+	GetFolder(orgID uuid.UUID, name string) (Folder, error)
+	// GetAllFolders returns the folders of every organization in the given
+	// order.
+	GetAllFolders(order FolderOrder) []Folder
 	// component 1
 	// Implement the following methods:
 	// GetAllChildFolders returns all child folders of a specific folder.
 	GetAllChildFolders(orgID uuid.UUID, name string) []Folder
 
+	// GetChildFolders returns the direct children of a specific folder.
+	GetChildFolders(orgID uuid.UUID, name string) []Folder
+	// GetDescendants returns the folders below a specific folder down to
+	// maxDepth levels, 1 being its direct children. A maxDepth of 0 returns
+	// the whole subtree.
+	GetDescendants(orgID uuid.UUID, name string, maxDepth int) ([]Folder, error)
+
+	// GetAncestors returns the folders from the top level folder down to a
+	// specific folder, the folder included.
+	GetAncestors(orgID uuid.UUID, name string) ([]Folder, error)
+	// GetParent returns the parent of a specific folder.
+	GetParent(orgID uuid.UUID, name string) (Folder, error)
+	// LowestCommonAncestor returns the deepest folder that is an ancestor of
+	// both a and b, where a folder counts as its own ancestor.
+	LowestCommonAncestor(orgID uuid.UUID, a string, b string) (Folder, error)
+
+	// GetFolderByPath returns the folder at a full path such as "a.b.c".
+	GetFolderByPath(orgID uuid.UUID, path string) (Folder, error)
+	// GetAllChildFoldersByPath returns all child folders of the folder at a
+	// full path.
+	GetAllChildFoldersByPath(orgID uuid.UUID, path string) []Folder
+
+	// GetFoldersByOrgIDPage returns up to limit folders of orgID, starting
+	// after cursor or from the first folder when cursor is empty.
+	GetFoldersByOrgIDPage(orgID uuid.UUID, limit int, cursor string) (Page, error)
+	// GetAllChildFoldersPage returns up to limit child folders of a specific
+	// folder, starting after cursor or from the first child when cursor is
+	// empty.
+	GetAllChildFoldersPage(orgID uuid.UUID, name string, limit int, cursor string) (Page, error)
+
 	// component 2
 	// Implement the following methods:
 	// MoveFolder moves a folder to a new destination.
+	// The destination is resolved inside the source folder's organization.
 	MoveFolder(name string, dst string) ([]Folder, error)
+	// MoveFolderInOrg moves a folder to a new destination, resolving both
+	// names inside orgID.
+	MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error)
+	// MoveFolderByPath moves the folder at srcPath under the folder at
+	// dstPath, or to the top level when dstPath is empty, resolving both
+	// paths inside orgID.
+	MoveFolderByPath(orgID uuid.UUID, srcPath string, dstPath string) ([]Folder, error)
+
+	// CreateFolder creates a folder under parentName, or a new top level
+	// folder when parentName is empty.
+	CreateFolder(orgID uuid.UUID, name string, parentName string) ([]Folder, error)
+	// DeleteFolder deletes a folder, along with its whole subtree when
+	// recursive is set. Folders with children are refused otherwise.
+	DeleteFolder(orgID uuid.UUID, name string, recursive bool) ([]Folder, error)
+	// RenameFolder renames a folder, updating the paths of all of its
+	// descendants.
+	RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error)
+	// MoveFolderToRoot detaches a folder from its parent, making it a top
+	// level folder of its organization.
+	MoveFolderToRoot(orgID uuid.UUID, name string) ([]Folder, error)
+	// TransferFolder moves a folder and its subtree under dstParent in
+	// dstOrgID, or to the top level of dstOrgID when dstParent is empty.
+	TransferFolder(name string, dstOrgID uuid.UUID, dstParent string) ([]Folder, error)
+	// CopyFolder copies a folder and its subtree under dstParent, or to the
+	// top level when dstParent is empty. Copies are named by nameFn, which
+	// defaults to SuffixNames when nil.
+	CopyFolder(orgID uuid.UUID, name string, dstParent string, nameFn NameFunc) ([]Folder, error)
+
+	// RenderTree writes the folder called name and its subtree to w as a
+	// tree, or every top level folder of orgID when name is empty.
+	RenderTree(w io.Writer, orgID uuid.UUID, name string, opts TreeOptions) error
 }
 
+// both lookups are keyed by OrgId first, folderTree holds each org's top level
+// folders and folderMap every folder of the org under its name
+// a name can belong to several folders under different parents, so folderMap
+// holds all of them
+// versions counts the changes to each org's trees so page cursors can tell
+// when they have gone stale
+// mu guards all fields, exported methods take it and unexported helpers
+// assume it is held
 type driver struct {
-	folderMap   map[string]*FolderTreeNode
-	folderTree  map[string]*FolderTreeNode
+	mu          sync.RWMutex
+	folderMap   map[uuid.UUID]map[string][]*FolderTreeNode
+	folderTree  map[uuid.UUID]map[string]*FolderTreeNode
 	folderSlice *[]Folder
+	versions    map[uuid.UUID]uint64
 }
 
 type FolderTreeNode struct {
@@ -35,9 +122,10 @@ type FolderTreeNode struct {
 
 func NewDriver(folders []Folder) IDriver {
 	f := &driver{
-		folderMap:   make(map[string]*FolderTreeNode, len(folders)),
-		folderTree:  make(map[string]*FolderTreeNode, len(folders)),
+		folderMap:   make(map[uuid.UUID]map[string][]*FolderTreeNode),
+		folderTree:  make(map[uuid.UUID]map[string]*FolderTreeNode),
 		folderSlice: &folders,
+		versions:    make(map[uuid.UUID]uint64),
 	}
 	buildFolderTree(&folders, &f.folderTree, &f.folderMap)
 	return f
@@ -57,9 +145,10 @@ func preProcessFolders(folders *[]Folder) {
 	})
 }
 
-// Builds the folderTree, inserting each node into the global name lookup map
+// Builds the folderTree, inserting each node into its org's name lookup map
 // Assumes well-formed folder trees in input which are properly seperated by OrgId
-func buildFolderTree(folders *[]Folder, folderTree, folderMap *map[string]*FolderTreeNode) {
+// parents are found by path, so names only need to be unique among siblings
+func buildFolderTree(folders *[]Folder, folderTree *map[uuid.UUID]map[string]*FolderTreeNode, folderMap *map[uuid.UUID]map[string][]*FolderTreeNode) {
 	if len(*folders) == 0 {
 		return
 	}
@@ -67,24 +156,230 @@ func buildFolderTree(folders *[]Folder, folderTree, folderMap *map[string]*Folde
 	preProcessFolders(folders)
 
 	// assumes folders sorted by path
+	byPath := make(map[orgPath]*FolderTreeNode, len(*folders))
 	for i := range *folders {
 		node := NewFolderTreeNode(&(*folders)[i])
+		orgID := node.folder.OrgId
+		if _, found := (*folderMap)[orgID]; !found {
+			(*folderMap)[orgID] = make(map[string][]*FolderTreeNode)
+			(*folderTree)[orgID] = make(map[string]*FolderTreeNode)
+		}
 
 		// assumes all folders have a valid path
-		paths := strings.Split(node.folder.Paths, ".")
-		if len(paths) == 1 {
-			(*folderTree)[(*folders)[i].Name] = node
+		parentPaths := parentPath(node.folder.Paths)
+		if parentPaths == "" {
+			(*folderTree)[orgID][(*folders)[i].Name] = node
 		} else {
-			(*folderMap)[paths[len(paths)-2]].children[(*folders)[i].Name] = node
-			node.parent = (*folderMap)[paths[len(paths)-2]]
+			parent := byPath[orgPath{orgID, parentPaths}]
+			parent.children[(*folders)[i].Name] = node
+			node.parent = parent
 		}
 
-		(*folderMap)[(*folders)[i].Name] = node
+		byPath[orgPath{orgID, node.folder.Paths}] = node
+		(*folderMap)[orgID][(*folders)[i].Name] = append((*folderMap)[orgID][(*folders)[i].Name], node)
 	}
 
 	return
 }
 
+// makes sure both lookups have an entry for orgID
+func (f *driver) addOrg(orgID uuid.UUID) {
+	if _, found := f.folderMap[orgID]; !found {
+		f.folderMap[orgID] = make(map[string][]*FolderTreeNode)
+		f.folderTree[orgID] = make(map[string]*FolderTreeNode)
+	}
+}
+
+// adds node to its org's name lookup
+func (f *driver) index(node *FolderTreeNode) {
+	orgID, name := node.folder.OrgId, node.folder.Name
+	f.folderMap[orgID][name] = append(f.folderMap[orgID][name], node)
+}
+
+// removes node from its org's name lookup
+func (f *driver) unindex(node *FolderTreeNode) {
+	orgID, name := node.folder.OrgId, node.folder.Name
+	nodes := slices.DeleteFunc(f.folderMap[orgID][name], func(other *FolderTreeNode) bool {
+		return other == node
+	})
+	if len(nodes) == 0 {
+		delete(f.folderMap[orgID], name)
+		return
+	}
+	f.folderMap[orgID][name] = nodes
+}
+
+// removes node from its parent's children, or from the org roots
+func (f *driver) detach(node *FolderTreeNode) {
+	f.versions[node.folder.OrgId]++
+	if node.parent == nil {
+		delete(f.folderTree[node.folder.OrgId], node.folder.Name)
+	} else {
+		delete(node.parent.children, node.folder.Name)
+	}
+	node.parent = nil
+}
+
+// adds node to the children of parent, or to the org roots when parent is nil
+func (f *driver) attach(node, parent *FolderTreeNode) {
+	f.versions[node.folder.OrgId]++
+	if parent == nil {
+		f.folderTree[node.folder.OrgId][node.folder.Name] = node
+	} else {
+		parent.children[node.folder.Name] = node
+	}
+	node.parent = parent
+}
+
+// appends folder to the backing slice and returns a pointer to the stored copy
+// the folder's node is attached afterwards, so relinking skips it and the
+// caller points it at the returned copy
+func (f *driver) appendFolder(folder Folder) *Folder {
+	prevCap := cap(*f.folderSlice)
+	*f.folderSlice = append(*f.folderSlice, folder)
+	if cap(*f.folderSlice) != prevCap {
+		f.relinkFolders()
+	}
+	return &(*f.folderSlice)[len(*f.folderSlice)-1]
+}
+
+// drops every folder in removed from the backing slice, keeping the order of
+// the remaining folders
+// the removed folders must already be detached from the tree
+func (f *driver) removeFolders(removed map[*Folder]struct{}) {
+	kept := (*f.folderSlice)[:0]
+	for i := range *f.folderSlice {
+		if _, found := removed[&(*f.folderSlice)[i]]; !found {
+			kept = append(kept, (*f.folderSlice)[i])
+		}
+	}
+	clear((*f.folderSlice)[len(kept):])
+	*f.folderSlice = kept
+	f.relinkFolders()
+}
+
+// points every node back at its entry in folderSlice
+// required whenever the slice is reallocated or compacted
+// entries are matched by path as names can repeat, resolve never reads the
+// folders the nodes still point at
+func (f *driver) relinkFolders() {
+	for i := range *f.folderSlice {
+		folder := &(*f.folderSlice)[i]
+		if node, found := f.resolve(folder.OrgId, folder.Paths); found {
+			node.folder = folder
+		}
+	}
+}
+
+// returns every node in the tree rooted at node, node included, in pre-order
+// with siblings visited in name order
+func (node *FolderTreeNode) collectNodes() []*FolderTreeNode {
+	var nodes []*FolderTreeNode
+	stack := []*FolderTreeNode{node}
+	for len(stack) > 0 {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		nodes = append(nodes, curr)
+		// pushed in reverse so the first name is popped first
+		children := sortedNodes(curr.children)
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, children[i])
+		}
+	}
+	return nodes
+}
+
+// returns the nodes in m ordered by name, the order every traversal visits
+// siblings and top level folders in
+func sortedNodes(m map[string]*FolderTreeNode) []*FolderTreeNode {
+	return sortedByName(slices.Collect(maps.Values(m)))
+}
+
+func sortedByName(nodes []*FolderTreeNode) []*FolderTreeNode {
+	slices.SortFunc(nodes, func(a, b *FolderTreeNode) int {
+		return strings.Compare(a.folder.Name, b.folder.Name)
+	})
+	return nodes
+}
+
+// finds the node for name inside orgID
+// a name shared by several folders is not found, they need resolve
+func (f *driver) lookup(orgID uuid.UUID, name string) (*FolderTreeNode, bool) {
+	node, err := f.find(orgID, name)
+	return node, err == nil
+}
+
+// finds the node for name inside orgID like lookup, returning
+// ErrFolderNotFound or ErrFolderAmbiguous when it can't
+func (f *driver) find(orgID uuid.UUID, name string) (*FolderTreeNode, error) {
+	switch nodes := f.folderMap[orgID][name]; len(nodes) {
+	case 0:
+		return nil, ErrFolderNotFound
+	case 1:
+		return nodes[0], nil
+	}
+	return nil, ErrFolderAmbiguous
+}
+
+// finds the node at path inside orgID by walking down from its top level
+// folder
+// only the keys of the children maps are read, so it works while nodes point at
+// stale folders
+// runs in O(d) in the depth of path
+func (f *driver) resolve(orgID uuid.UUID, path string) (*FolderTreeNode, bool) {
+	var node *FolderTreeNode
+	nodes := f.folderTree[orgID]
+	for _, segment := range strings.Split(path, ".") {
+		next, found := nodes[segment]
+		if !found {
+			return nil, false
+		}
+		node, nodes = next, next.children
+	}
+	return node, true
+}
+
+// reports whether any folder in orgID is called name
+func (f *driver) nameInUse(orgID uuid.UUID, name string) bool {
+	return len(f.folderMap[orgID][name]) > 0
+}
+
+// reports whether name is missing from orgID but used in another org
+func (f *driver) onlyInOtherOrg(orgID uuid.UUID, name string) bool {
+	if f.nameInUse(orgID, name) {
+		return false
+	}
+	for otherID := range f.folderMap {
+		if otherID != orgID && f.nameInUse(otherID, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// finds every node called name across all orgs
+// runs in O(m) in the number of orgs
+func (f *driver) lookupAllOrgs(name string) []*FolderTreeNode {
+	var nodes []*FolderTreeNode
+	for _, orgFolders := range f.folderMap {
+		nodes = append(nodes, orgFolders[name]...)
+	}
+	return nodes
+}
+
+// returns the folders that belong to orgID, mutations return every org's
+// folders and callers acting for one org only show it theirs
+func FoldersInOrg(folders []Folder, orgID uuid.UUID) []Folder {
+	orgFolders := []Folder{}
+	for _, folder := range folders {
+		if folder.OrgId == orgID {
+			orgFolders = append(orgFolders, folder)
+		}
+	}
+	return orgFolders
+}
+
 // used to ensure unordered slices are ordered in the output to match tests that
 // request it
 func SortFoldersByPath(folders []Folder) []Folder {
@@ -1,6 +1,11 @@
 package folder
 
 import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/gofrs/uuid"
 )
 
@@ -8,67 +13,220 @@ func GetAllFolders() []Folder {
 	return GetSampleData()
 }
 
+// returns Folder name in orgID, so callers can tell a missing or shared name
+// apart from a folder without children
+// runs in O(1)
+func (f *driver) GetFolder(orgID uuid.UUID, name string) (Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return Folder{}, err
+	}
+	return *node.folder, nil
+}
+
 func (f *driver) GetFoldersByOrgID(orgID uuid.UUID) []Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
 	var folders []Folder
-	for _, folder := range f.folderTree {
-		if folder.folder.OrgId == orgID {
-			folders = append(folders, folder.collectFoldersInOrder()...)
-		}
+	for _, folder := range sortedNodes(f.folderTree[orgID]) {
+		folders = append(folders, folder.collectFoldersInOrder()...)
 	}
 
-	// I chose in-order traversal here, this function could be extended to
-	// support different output orderings as required
 	return folders
 }
 
-// returns folders in Org in-order
+// returns the folders in the tree rooted at fol in pre-order, parents before
+// their children and siblings in name order
 func (fol *FolderTreeNode) collectFoldersInOrder() []Folder {
-	var folders []Folder
-	stack := []*FolderTreeNode{fol}
+	nodes := fol.collectNodes()
+	folders := make([]Folder, len(nodes))
+	for i, node := range nodes {
+		folders[i] = *node.folder
+	}
 
-	for len(stack) > 0 {
-		curr := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
+	return folders
+}
 
-		if curr.folder != nil {
-			folders = append(folders, *curr.folder)
-		}
+func (f *driver) GetAllChildFolders(orgID uuid.UUID, name string) []Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 
-		for _, child := range curr.children {
-			stack = append(stack, child)
-		}
+	namedFolder, found := f.lookup(orgID, name)
+	if !found {
+		return nil
+	}
+	return namedFolder.childFolders()
+}
+
+// returns the folders directly inside Folder name, in name order
+// runs in O(c log c) in the number of children
+func (f *driver) GetChildFolders(orgID uuid.UUID, name string) []Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, found := f.lookup(orgID, name)
+	if !found || len(node.children) == 0 {
+		return nil
 	}
 
+	children := sortedNodes(node.children)
+	folders := make([]Folder, len(children))
+	for i, child := range children {
+		folders[i] = *child.folder
+	}
 	return folders
 }
 
-func (f *driver) GetAllChildFolders(orgID uuid.UUID, name string) []Folder {
-	namedFolder, found := f.folderMap[name]
-	if !found || namedFolder.folder.OrgId != orgID {
-		return nil
+// returns the folders below Folder name in pre-order, stopping maxDepth levels
+// down, a maxDepth of 0 or less returns the whole subtree like
+// GetAllChildFolders
+// unlike GetAllChildFolders a missing or shared name is an error, so callers
+// can tell it apart from a folder without children
+// only the levels returned are walked
+func (f *driver) GetDescendants(orgID uuid.UUID, name string, maxDepth int) ([]Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	if maxDepth <= 0 {
+		return node.childFolders(), nil
 	}
 
-	var folders []Folder
-	stack := []*FolderTreeNode{namedFolder}
+	type entry struct {
+		node  *FolderTreeNode
+		depth int
+	}
 
+	var folders []Folder
+	stack := []entry{{node, 0}}
 	for len(stack) > 0 {
 		curr := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
 
-		if curr.folder != nil && curr.folder.Name != name {
-			folders = append(folders, *curr.folder)
+		if curr.node != node {
+			folders = append(folders, *curr.node.folder)
+		}
+		if curr.depth == maxDepth {
+			continue
+		}
+		// pushed in reverse so the first name is popped first
+		children := sortedNodes(curr.node.children)
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, entry{children[i], curr.depth + 1})
 		}
+	}
+	return folders, nil
+}
+
+// returns the folder at path in orgID, path is the full dotted path such as
+// "a.b.c" and tells apart folders that share a name
+// runs in O(d) in the depth of path
+func (f *driver) GetFolderByPath(orgID uuid.UUID, path string) (Folder, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 
-		for _, child := range curr.children {
-			stack = append(stack, child)
+	node, found := f.resolve(orgID, path)
+	if !found {
+		return Folder{}, ErrFolderNotFound
+	}
+	return *node.folder, nil
+}
+
+func (f *driver) GetAllChildFoldersByPath(orgID uuid.UUID, path string) []Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, found := f.resolve(orgID, path)
+	if !found {
+		return nil
+	}
+	return node.childFolders()
+}
+
+// returns the folders below fol in pre-order, or nil when it has no children
+func (fol *FolderTreeNode) childFolders() []Folder {
+	// fol itself comes first
+	folders := fol.collectFoldersInOrder()[1:]
+	if len(folders) == 0 {
+		return nil
+	}
+	return folders
+}
+
+// FolderOrder picks the order GetAllFolders returns folders in.
+type FolderOrder int
+
+const (
+	// OrderByPath sorts folders by path, comparing one segment at a time so
+	// every folder is followed by its subtree. Folders with the same path in
+	// different organizations are ordered by org ID.
+	OrderByPath FolderOrder = iota
+	// OrderByName sorts folders by name, then by org ID and path.
+	OrderByName
+	// OrderPreOrder walks each organization's trees depth first, parents
+	// before their children and siblings in name order, the same order
+	// GetFoldersByOrgID uses. Organizations are ordered by org ID.
+	OrderPreOrder
+)
+
+// returns all folders on f in the given order
+func (f *driver) GetAllFolders(order FolderOrder) []Folder {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	switch order {
+	case OrderByName:
+		folders := f.allFolders()
+		slices.SortFunc(folders, func(a, b Folder) int {
+			if c := strings.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			if c := compareOrgIDs(a.OrgId, b.OrgId); c != 0 {
+				return c
+			}
+			return comparePaths(a.Paths, b.Paths)
+		})
+		return folders
+
+	case OrderPreOrder:
+		orgIDs := slices.SortedFunc(maps.Keys(f.folderTree), compareOrgIDs)
+		folders := make([]Folder, 0, len(*f.folderSlice))
+		for _, orgID := range orgIDs {
+			for _, root := range sortedNodes(f.folderTree[orgID]) {
+				folders = append(folders, root.collectFoldersInOrder()...)
+			}
 		}
+		return folders
 	}
 
+	folders := f.allFolders()
+	slices.SortFunc(folders, func(a, b Folder) int {
+		if c := comparePaths(a.Paths, b.Paths); c != 0 {
+			return c
+		}
+		return compareOrgIDs(a.OrgId, b.OrgId)
+	})
 	return folders
 }
 
-// returns all folders on f
-// folders are collected for earch Org by seperate goroutines
-func (f *driver) GetAllFolders() []Folder {
-	return *f.folderSlice
+// compares paths segment by segment, so "a.b" sorts before "a-b"
+func comparePaths(a, b string) int {
+	return slices.Compare(strings.Split(a, "."), strings.Split(b, "."))
+}
+
+func compareOrgIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a.Bytes(), b.Bytes())
+}
+
+// returns a copy of the backing slice so callers never share memory with
+// folders that later mutations update in place
+func (f *driver) allFolders() []Folder {
+	return slices.Clone(*f.folderSlice)
 }
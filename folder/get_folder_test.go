@@ -2,9 +2,12 @@ package folder_test
 
 import (
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
 	"github.com/gofrs/uuid"
 )
 
@@ -143,6 +146,21 @@ func Test_folder_GetFoldersByOrgID(t *testing.T) {
 			nil,
 			errors.New("Organization does not exist"),
 		},
+		{
+			"same root name in different organizations",
+			firstOrgId,
+			[]folder.Folder{
+				{"archive", firstOrgId, "archive"},
+				{"bravo", firstOrgId, "archive.bravo"},
+				{"archive", secondOrgId, "archive"},
+				{"bravo", secondOrgId, "archive.bravo"},
+			},
+			[]folder.Folder{
+				{"archive", firstOrgId, "archive"},
+				{"bravo", firstOrgId, "archive.bravo"},
+			},
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -154,6 +172,195 @@ func Test_folder_GetFoldersByOrgID(t *testing.T) {
 	}
 }
 
+func Test_folder_GetAllFolders(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	// SecondOrgID sorts before FirstOrgID
+	mixed := func() []folder.Folder {
+		return []folder.Folder{
+			{"alpha-two", firstOrgId, "alpha-two"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"alpha", firstOrgId, "alpha"},
+			{"charlie", secondOrgId, "alpha.charlie"},
+			{"alpha", secondOrgId, "alpha"},
+		}
+	}
+	branching := func() []folder.Folder {
+		return []folder.Folder{
+			{"charlie", firstOrgId, "alpha.charlie"},
+			{"zulu", firstOrgId, "zulu"},
+			{"alpha", firstOrgId, "alpha"},
+			{"echo", secondOrgId, "delta.echo"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"delta", secondOrgId, "delta"},
+			{"foxtrot", firstOrgId, "alpha.bravo.foxtrot"},
+		}
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		order   folder.FolderOrder
+		folders []folder.Folder
+		want    []folder.Folder
+	}{
+		{
+			"no folders",
+			folder.OrderByPath,
+			[]folder.Folder{},
+			[]folder.Folder{},
+		},
+		{
+			"by path",
+			folder.OrderByPath,
+			mixed(),
+			[]folder.Folder{
+				{"alpha", secondOrgId, "alpha"},
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", secondOrgId, "alpha.charlie"},
+				{"alpha-two", firstOrgId, "alpha-two"},
+			},
+		},
+		{
+			"by name",
+			folder.OrderByName,
+			mixed(),
+			[]folder.Folder{
+				{"alpha", secondOrgId, "alpha"},
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha-two", firstOrgId, "alpha-two"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", secondOrgId, "alpha.charlie"},
+			},
+		},
+		{
+			"pre-order",
+			folder.OrderPreOrder,
+			branching(),
+			[]folder.Folder{
+				{"delta", secondOrgId, "delta"},
+				{"echo", secondOrgId, "delta.echo"},
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"foxtrot", firstOrgId, "alpha.bravo.foxtrot"},
+				{"charlie", firstOrgId, "alpha.charlie"},
+				{"zulu", firstOrgId, "zulu"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got := f.GetAllFolders(tt.order)
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatalf("GetAllFolders output is not in order:\n%s", strings.Join(diff, "\n"))
+			}
+		})
+	}
+}
+
+func Test_folder_GetAllFolders_AfterMove(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "charlie"},
+	})
+	if _, err := f.MoveFolder("charlie", "bravo"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+	}
+	for _, order := range []folder.FolderOrder{folder.OrderByPath, folder.OrderPreOrder} {
+		if diff := deep.Equal(f.GetAllFolders(order), want); diff != nil {
+			t.Errorf("order %d: %s", order, strings.Join(diff, "\n"))
+		}
+	}
+}
+
+func Test_folder_ReadOrder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	folders := []folder.Folder{
+		{"zulu", firstOrgId, "zulu"},
+		{"mike", firstOrgId, "alpha.mike"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"yankee", firstOrgId, "alpha.delta.yankee"},
+		{"bravo", firstOrgId, "alpha.delta.bravo"},
+		{"alpha", firstOrgId, "alpha"},
+		{"kilo", firstOrgId, "kilo"},
+		{"charlie", firstOrgId, "alpha.charlie"},
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name string
+		read func(t *testing.T, f folder.IDriver) []folder.Folder
+		want []folder.Folder
+	}{
+		{
+			"GetFoldersByOrgID",
+			func(t *testing.T, f folder.IDriver) []folder.Folder { return f.GetFoldersByOrgID(firstOrgId) },
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "alpha.charlie"},
+				{"delta", firstOrgId, "alpha.delta"},
+				{"bravo", firstOrgId, "alpha.delta.bravo"},
+				{"yankee", firstOrgId, "alpha.delta.yankee"},
+				{"mike", firstOrgId, "alpha.mike"},
+				{"kilo", firstOrgId, "kilo"},
+				{"zulu", firstOrgId, "zulu"},
+			},
+		},
+		{
+			"GetAllChildFolders",
+			func(t *testing.T, f folder.IDriver) []folder.Folder { return f.GetAllChildFolders(firstOrgId, "alpha") },
+			[]folder.Folder{
+				{"charlie", firstOrgId, "alpha.charlie"},
+				{"delta", firstOrgId, "alpha.delta"},
+				{"bravo", firstOrgId, "alpha.delta.bravo"},
+				{"yankee", firstOrgId, "alpha.delta.yankee"},
+				{"mike", firstOrgId, "alpha.mike"},
+			},
+		},
+		{
+			"after a move",
+			func(t *testing.T, f folder.IDriver) []folder.Folder {
+				if _, err := f.MoveFolder("zulu", "delta"); err != nil {
+					t.Fatal(err)
+				}
+				return f.GetAllChildFolders(firstOrgId, "delta")
+			},
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.delta.bravo"},
+				{"yankee", firstOrgId, "alpha.delta.yankee"},
+				{"zulu", firstOrgId, "alpha.delta.zulu"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// map iteration order changes between drivers, so a few fresh ones
+			// have to agree
+			for range 20 {
+				f := folder.NewDriver(slices.Clone(folders))
+				if diff := deep.Equal(tt.read(t, f), tt.want); diff != nil {
+					t.Fatalf("%s output is not in order:\n%s", tt.name, strings.Join(diff, "\n"))
+				}
+			}
+		})
+	}
+}
+
 func Test_folder_GetAllChildFolders(t *testing.T) {
 	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
 	secondOrgID := uuid.FromStringOrNil(SecondOrgID)
@@ -326,6 +533,24 @@ func Test_folder_GetAllChildFolders(t *testing.T) {
 			nil,
 			errors.New("Folder does not exist in the specified organization"),
 		},
+		{
+			"same folder name in different organizations",
+			secondOrgID,
+			"archive",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "alpha.archive"},
+				{"bravo", firstOrgId, "alpha.archive.bravo"},
+				{"archive", secondOrgID, "archive"},
+				{"charlie", secondOrgID, "archive.charlie"},
+				{"delta", secondOrgID, "archive.charlie.delta"},
+			},
+			[]folder.Folder{
+				{"charlie", secondOrgID, "archive.charlie"},
+				{"delta", secondOrgID, "archive.charlie.delta"},
+			},
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,3 +606,281 @@ func Benchmark_folder_GetAllChildFolders_small_subtree(b *testing.B) {
 		f.GetAllChildFolders(orgID, "noted-lady-bullseye")
 	}
 }
+
+func Test_folder_GetFolderByPath(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	// bravo is used under two parents
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "delta"},
+		{"bravo", firstOrgId, "delta.bravo"},
+		{"echo", firstOrgId, "delta.bravo.echo"},
+		{"alpha", secondOrgId, "alpha"},
+	})
+
+	t.Parallel()
+	tests := [...]struct {
+		name     string
+		orgID    uuid.UUID
+		path     string
+		want     folder.Folder
+		children []folder.Folder
+		err      error
+	}{
+		{
+			"top level folder",
+			firstOrgId,
+			"alpha",
+			folder.Folder{"alpha", firstOrgId, "alpha"},
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			nil,
+		},
+		{
+			"shared name under first parent",
+			firstOrgId,
+			"alpha.bravo",
+			folder.Folder{"bravo", firstOrgId, "alpha.bravo"},
+			[]folder.Folder{
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			nil,
+		},
+		{
+			"shared name under second parent",
+			firstOrgId,
+			"delta.bravo",
+			folder.Folder{"bravo", firstOrgId, "delta.bravo"},
+			[]folder.Folder{
+				{"echo", firstOrgId, "delta.bravo.echo"},
+			},
+			nil,
+		},
+		{
+			"leaf folder",
+			firstOrgId,
+			"delta.bravo.echo",
+			folder.Folder{"echo", firstOrgId, "delta.bravo.echo"},
+			nil,
+			nil,
+		},
+		{
+			"same path in another organization",
+			secondOrgId,
+			"alpha",
+			folder.Folder{"alpha", secondOrgId, "alpha"},
+			nil,
+			nil,
+		},
+		{
+			"bare name of a nested folder",
+			firstOrgId,
+			"charlie",
+			folder.Folder{},
+			nil,
+			folder.ErrFolderNotFound,
+		},
+		{
+			"path missing from organization",
+			secondOrgId,
+			"alpha.bravo",
+			folder.Folder{},
+			nil,
+			folder.ErrFolderNotFound,
+		},
+		{
+			"empty path",
+			firstOrgId,
+			"",
+			folder.Folder{},
+			nil,
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.GetFolderByPath(tt.orgID, tt.path)
+			testFolderError(t, err, tt.err)
+			if got != tt.want {
+				t.Fatalf("GetFolderByPath wanted=%v. got=%v", tt.want, got)
+			}
+
+			testFolderResults(t, f.GetAllChildFoldersByPath(tt.orgID, tt.path), tt.children)
+		})
+	}
+}
+
+func Test_folder_SharedNames(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"delta", firstOrgId, "delta"},
+		{"bravo", firstOrgId, "delta.bravo"},
+	})
+
+	// a shared name can't pick a folder, so the name based methods report it
+	if got := f.GetAllChildFolders(firstOrgId, "alpha"); len(got) != 1 {
+		t.Fatalf("GetAllChildFolders(alpha) = %v, want alpha.bravo", got)
+	}
+	_, err := f.GetFolder(firstOrgId, "bravo")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.GetFolder(firstOrgId, "charlie")
+	testFolderError(t, err, folder.ErrFolderNotFound)
+	_, err = f.RenameFolder(firstOrgId, "bravo", "charlie")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.CreateFolder(firstOrgId, "bravo", "")
+	testFolderError(t, err, folder.ErrFolderExists)
+	_, err = f.CreateFolder(firstOrgId, "charlie", "bravo")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.MoveFolderInOrg(firstOrgId, "bravo", "delta")
+	testFolderError(t, err, folder.ErrSourceAmbiguous)
+	_, err = f.MoveFolderInOrg(firstOrgId, "delta", "bravo")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.GetParent(firstOrgId, "bravo")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.GetAncestors(firstOrgId, "bravo")
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+	_, err = f.GetDescendants(firstOrgId, "bravo", 0)
+	testFolderError(t, err, folder.ErrFolderAmbiguous)
+
+	// once one of them moves away the other is unique again
+	_, err = f.DeleteFolder(firstOrgId, "delta", true)
+	testFolderError(t, err, nil)
+	got, err := f.RenameFolder(firstOrgId, "bravo", "charlie")
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"charlie", firstOrgId, "alpha.charlie"},
+	})
+	folderGot, err := f.GetFolder(firstOrgId, "charlie")
+	testFolderError(t, err, nil)
+	if folderGot != (folder.Folder{"charlie", firstOrgId, "alpha.charlie"}) {
+		t.Fatalf("GetFolder(charlie) = %v", folderGot)
+	}
+}
+
+func Test_folder_GetDescendants(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"echo", firstOrgId, "alpha.delta.echo"},
+		{"foxtrot", firstOrgId, "alpha.delta.echo.foxtrot"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"golf", firstOrgId, "golf"},
+		{"hotel", secondOrgId, "hotel"},
+		{"india", secondOrgId, "hotel.india"},
+	})
+
+	t.Parallel()
+	tests := [...]struct {
+		name     string
+		orgID    uuid.UUID
+		target   string
+		maxDepth int
+		want     []folder.Folder
+		err      error
+	}{
+		{
+			"direct children",
+			firstOrgId,
+			"alpha",
+			1,
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			nil,
+		},
+		{
+			"two levels",
+			firstOrgId,
+			"alpha",
+			2,
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.delta"},
+				{"echo", firstOrgId, "alpha.delta.echo"},
+			},
+			nil,
+		},
+		{
+			"deeper than the tree",
+			firstOrgId,
+			"delta",
+			10,
+			[]folder.Folder{
+				{"echo", firstOrgId, "alpha.delta.echo"},
+				{"foxtrot", firstOrgId, "alpha.delta.echo.foxtrot"},
+			},
+			nil,
+		},
+		{
+			"zero depth returns the whole subtree",
+			firstOrgId,
+			"alpha",
+			0,
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.delta"},
+				{"echo", firstOrgId, "alpha.delta.echo"},
+				{"foxtrot", firstOrgId, "alpha.delta.echo.foxtrot"},
+			},
+			nil,
+		},
+		{
+			"no children",
+			firstOrgId,
+			"golf",
+			1,
+			nil,
+			nil,
+		},
+		{
+			"folder in another organization",
+			secondOrgId,
+			"alpha",
+			1,
+			nil,
+			folder.ErrFolderNotFound,
+		},
+		{
+			"missing folder",
+			firstOrgId,
+			"invalid",
+			1,
+			nil,
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.GetDescendants(tt.orgID, tt.target, tt.maxDepth)
+			testFolderError(t, err, tt.err)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatalf("GetDescendants output does not match expected:\n%s", diff)
+			}
+
+			// GetChildFolders is the one level view
+			if tt.maxDepth == 1 {
+				if diff := deep.Equal(f.GetChildFolders(tt.orgID, tt.target), tt.want); diff != nil {
+					t.Fatalf("GetChildFolders output does not match expected:\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package folder_test
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -31,18 +32,12 @@ func testFolderResults(t *testing.T, got []folder.Folder, want []folder.Folder)
 }
 
 // helper function for testing the errors returned by folder IDriver interface
-// functions that return errors
+// functions that return errors, expErr is matched with errors.Is
 func testFolderError(t *testing.T, gotErr error, expErr error) {
-	errString := "<nil>"
-	ttErrString := "<nil>"
-	if gotErr != nil {
-		errString = gotErr.Error()
+	if expErr == nil && gotErr == nil {
+		return
 	}
-	if expErr != nil {
-		ttErrString = expErr.Error()
-	}
-	if errString != ttErrString {
-		t.Fatalf("GetFoldersByOrgID wanted=%s. got=%s\n",
-			ttErrString, errString)
+	if expErr == nil || !errors.Is(gotErr, expErr) {
+		t.Fatalf("IDriver error wanted=%v. got=%v\n", expErr, gotErr)
 	}
 }
@@ -0,0 +1,172 @@
+package folder
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+// LoggedDriver wraps an IDriver and records every mutation in an OpLog before
+// applying it, reads go straight to the wrapped driver.
+// mu serialises mutations so the log order matches the order they were applied
+// in.
+type LoggedDriver struct {
+	IDriver
+	mu  sync.Mutex
+	log *OpLog
+}
+
+// builds a driver from snapshot and replays the log at logPath on top of it
+// a log compacted by Compact starts with its own snapshot, which replaces
+// snapshot
+// an op is logged before it is applied, so a crash can leave one the driver
+// went on to reject at the end of the log, it is rejected again and skipped
+// a snapshot that fails ValidateFolders is refused with a *ValidationError
+func NewDriverWithLog(snapshot []Folder, logPath string) (*LoggedDriver, error) {
+	log, ops, err := OpenOpLog(logPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ops) > 0 && ops[0].Kind == OpSnapshot {
+		snapshot = ops[0].Folders
+		ops = ops[1:]
+	}
+
+	driver, err := NewDriverWithValidation(slices.Clone(snapshot))
+	if err != nil {
+		log.Close()
+		return nil, err
+	}
+	for _, op := range ops {
+		op.apply(driver)
+	}
+
+	return &LoggedDriver{IDriver: driver, log: log}, nil
+}
+
+// replaces the log with a single snapshot of the current folders
+// the log file is swapped atomically so a crash leaves either the old log or
+// the compacted one
+func (d *LoggedDriver) Compact() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	folders := d.IDriver.GetAllFolders(OrderPreOrder)
+	return d.log.Rewrite([]Op{{Kind: OpSnapshot, Folders: folders}})
+}
+
+func (d *LoggedDriver) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.log.Close()
+}
+
+// records op and then applies it, the caller must hold mu
+// an op the driver rejects is cut back off the log, so the log only holds
+// mutations that were made
+func (d *LoggedDriver) apply(op Op) ([]Folder, error) {
+	size := d.log.size
+	if err := d.log.Append(op); err != nil {
+		return []Folder{}, err
+	}
+
+	folders, err := op.apply(d.IDriver)
+	if err != nil {
+		// left in place the op is only rejected again on replay
+		d.log.truncate(size)
+	}
+	return folders, err
+}
+
+func (d *LoggedDriver) logAndApply(op Op) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.apply(op)
+}
+
+func (d *LoggedDriver) MoveFolder(name string, dst string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpMove, Name: name, Target: dst})
+}
+
+func (d *LoggedDriver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpMoveInOrg, OrgID: orgID, Name: name, Target: dst})
+}
+
+func (d *LoggedDriver) MoveFolderByPath(orgID uuid.UUID, srcPath string, dstPath string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpMoveByPath, OrgID: orgID, Name: srcPath, Target: dstPath})
+}
+
+func (d *LoggedDriver) CreateFolder(orgID uuid.UUID, name string, parentName string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpCreate, OrgID: orgID, Name: name, Target: parentName})
+}
+
+func (d *LoggedDriver) DeleteFolder(orgID uuid.UUID, name string, recursive bool) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpDelete, OrgID: orgID, Name: name, Recursive: recursive})
+}
+
+func (d *LoggedDriver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpRename, OrgID: orgID, Name: oldName, Target: newName})
+}
+
+func (d *LoggedDriver) MoveFolderToRoot(orgID uuid.UUID, name string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpMoveToRoot, OrgID: orgID, Name: name})
+}
+
+func (d *LoggedDriver) TransferFolder(name string, dstOrgID uuid.UUID, dstParent string) ([]Folder, error) {
+	return d.logAndApply(Op{Kind: OpTransfer, Name: name, DstOrgID: dstOrgID, Target: dstParent})
+}
+
+// nameFn can't be written to the log, so the copy names are picked up front
+// and logged instead
+func (d *LoggedDriver) CopyFolder(orgID uuid.UUID, name string, dstParent string, nameFn NameFunc) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	copies, err := planCopyNames(d.IDriver, orgID, name, nameFn)
+	if err != nil {
+		return []Folder{}, err
+	}
+	return d.apply(Op{Kind: OpCopy, OrgID: orgID, Name: name, Target: dstParent, Copies: copies})
+}
+
+// picks the copy name for every folder in the subtree rooted at name, in the
+// pre-order CopyFolder visits them in, folders sharing a name each get their
+// own copy name
+// a missing folder yields no names and is left for the driver to reject
+func planCopyNames(driver IDriver, orgID uuid.UUID, name string, nameFn NameFunc) ([]string, error) {
+	if nameFn == nil {
+		nameFn = SuffixNames
+	}
+
+	inOrg := make(map[string]struct{})
+	for _, folder := range driver.GetFoldersByOrgID(orgID) {
+		inOrg[folder.Name] = struct{}{}
+	}
+	if _, found := inOrg[name]; !found {
+		return nil, nil
+	}
+
+	subtree := []string{name}
+	for _, child := range driver.GetAllChildFolders(orgID, name) {
+		subtree = append(subtree, child.Name)
+	}
+
+	inUse := func(candidate string) bool {
+		_, found := inOrg[candidate]
+		return found
+	}
+	copies := make([]string, 0, len(subtree))
+	for _, source := range subtree {
+		copyName, err := freeName(source, nameFn, inUse)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, copyName)
+		inOrg[copyName] = struct{}{}
+	}
+	return copies, nil
+}
@@ -2,53 +2,196 @@ package folder
 
 import (
 	"errors"
-	"slices"
 	"strings"
+
+	"github.com/gofrs/uuid"
 )
 
 // moves Folder name to be a child of Folder dst
+// dst is resolved inside the org that owns name
 // runs in O(n) in length of folders due to path updates
 func (f *driver) MoveFolder(name string, dst string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if name == dst {
+		return []Folder{}, &MoveError{name, dst, uuid.Nil, ErrMoveToSelf}
+	}
+
+	sources := f.lookupAllOrgs(name)
+	if len(sources) == 0 {
+		return []Folder{}, &MoveError{name, dst, uuid.Nil, ErrSourceNotFound}
+	}
+	if len(sources) > 1 {
+		return []Folder{}, &MoveError{name, dst, uuid.Nil, ErrSourceAmbiguous}
+	}
+	fromFolder := sources[0]
+
+	orgID := fromFolder.folder.OrgId
+	toFolder, err := f.findDestination(orgID, dst)
+	if err != nil {
+		if errors.Is(err, ErrDestinationNotFound) && f.onlyInOtherOrg(orgID, dst) {
+			err = ErrCrossOrgMove
+		}
+		return []Folder{}, &MoveError{name, dst, orgID, err}
+	}
+
+	if err := f.moveFolder(fromFolder, toFolder); err != nil {
+		return []Folder{}, &MoveError{name, dst, orgID, err}
+	}
+	return f.allFolders(), nil
+}
+
+// moves Folder name to be a child of Folder dst, both resolved inside orgID
+func (f *driver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if name == dst {
-		return []Folder{}, errors.New("Cannot move a folder to itself")
+		return []Folder{}, &MoveError{name, dst, orgID, ErrMoveToSelf}
+	}
+
+	fromFolder, err := f.findSource(orgID, name)
+	if err != nil {
+		return []Folder{}, &MoveError{name, dst, orgID, err}
 	}
 
-	fromFolder, found := f.folderMap[name]
+	toFolder, err := f.findDestination(orgID, dst)
+	if err != nil {
+		return []Folder{}, &MoveError{name, dst, orgID, err}
+	}
+
+	if err := f.moveFolder(fromFolder, toFolder); err != nil {
+		return []Folder{}, &MoveError{name, dst, orgID, err}
+	}
+	return f.allFolders(), nil
+}
+
+// moves the folder at srcPath to be a child of the folder at dstPath, both
+// resolved inside orgID
+// an empty dstPath moves the folder to the top level of orgID
+// runs in O(k) in the size of the subtree at srcPath due to path updates
+func (f *driver) MoveFolderByPath(orgID uuid.UUID, srcPath string, dstPath string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if srcPath == dstPath {
+		return []Folder{}, &MoveError{srcPath, dstPath, orgID, ErrMoveToSelf}
+	}
+
+	fromFolder, found := f.resolve(orgID, srcPath)
 	if !found {
-		return []Folder{}, errors.New("Source folder does not exist")
+		return []Folder{}, &MoveError{srcPath, dstPath, orgID, ErrSourceNotFound}
+	}
+
+	if dstPath == "" {
+		if err := f.moveToRoot(fromFolder); err != nil {
+			return []Folder{}, &MoveError{srcPath, dstPath, orgID, err}
+		}
+		return f.allFolders(), nil
 	}
 
-	toFolder, found := f.folderMap[dst]
+	toFolder, found := f.resolve(orgID, dstPath)
 	if !found {
-		return []Folder{}, errors.New("Destination folder does not exist")
+		return []Folder{}, &MoveError{srcPath, dstPath, orgID, ErrDestinationNotFound}
 	}
 
-	if fromFolder.folder.OrgId != toFolder.folder.OrgId {
-		return []Folder{}, errors.New("Cannot move a folder to a different organization")
+	if err := f.moveFolder(fromFolder, toFolder); err != nil {
+		return []Folder{}, &MoveError{srcPath, dstPath, orgID, err}
 	}
-	if slices.Contains(strings.Split(toFolder.folder.Paths, "."), fromFolder.folder.Name) {
-		return []Folder{}, errors.New("Cannot move a folder to a child of itself")
+	return f.allFolders(), nil
+}
+
+// finds the folder a move starts from, a name shared by several folders is
+// ErrSourceAmbiguous
+func (f *driver) findSource(orgID uuid.UUID, name string) (*FolderTreeNode, error) {
+	node, err := f.find(orgID, name)
+	switch {
+	case errors.Is(err, ErrFolderAmbiguous):
+		return nil, ErrSourceAmbiguous
+	case err != nil:
+		return nil, ErrSourceNotFound
 	}
+	return node, nil
+}
 
-	// update position
-	toFolder.children[fromFolder.folder.Name] = fromFolder
-	if fromFolder.parent != nil {
-		delete(fromFolder.parent.children, fromFolder.folder.Name)
-		toFolder.children[fromFolder.folder.Name] = fromFolder
-		fromFolder.parent = toFolder
+// finds the folder a move ends under, a name shared by several folders is
+// ErrFolderAmbiguous
+func (f *driver) findDestination(orgID uuid.UUID, name string) (*FolderTreeNode, error) {
+	node, err := f.find(orgID, name)
+	if errors.Is(err, ErrFolderNotFound) {
+		return nil, ErrDestinationNotFound
 	}
+	return node, err
+}
+
+// reattaches fromFolder under toFolder, both nodes must belong to the same org
+// returns the reason the move is refused, for the caller to wrap in a MoveError
+func (f *driver) moveFolder(fromFolder, toFolder *FolderTreeNode) error {
+	for curr := toFolder; curr != nil; curr = curr.parent {
+		if curr == fromFolder {
+			return ErrMoveIntoDescendant
+		}
+	}
+	if sibling, found := toFolder.children[fromFolder.folder.Name]; found && sibling != fromFolder {
+		return ErrFolderExists
+	}
+
+	// update position
+	f.detach(fromFolder)
+	f.attach(fromFolder, toFolder)
 
 	// update paths
 	fixPaths(fromFolder, toFolder.folder.Paths)
 
-	return f.GetAllFolders(), nil
+	return nil
+}
+
+// moves Folder name in orgID to the top level of orgID
+// moving a folder that is already top level is a no-op
+func (f *driver) MoveFolderToRoot(orgID uuid.UUID, name string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	node, err := f.findSource(orgID, name)
+	if err != nil {
+		return []Folder{}, &MoveError{name, "", orgID, err}
+	}
+	if err := f.moveToRoot(node); err != nil {
+		return []Folder{}, &MoveError{name, "", orgID, err}
+	}
+
+	return f.allFolders(), nil
+}
+
+// reattaches node as a top level folder of its org
+// refused when another top level folder already has its name
+func (f *driver) moveToRoot(node *FolderTreeNode) error {
+	if node.parent == nil {
+		return nil
+	}
+	if _, found := f.folderTree[node.folder.OrgId][node.folder.Name]; found {
+		return ErrFolderExists
+	}
+
+	f.detach(node)
+	f.attach(node, nil)
+	fixPaths(node, "")
+	return nil
 }
 
 // updates the paths for all nodes in the tree rooted at node
 // node rooted at newPrefix, children are updated as required
 func fixPaths(node *FolderTreeNode, newPrefix string) {
 	paths := strings.Split(node.folder.Paths, ".")
-	oldPrefix := strings.Trim(strings.Join(paths[:len(paths)-1], "."), ".")
+	oldPrefix := strings.Join(paths[:len(paths)-1], ".")
+	replacePathPrefix(node, oldPrefix, newPrefix)
+}
+
+// replaces oldPrefix with newPrefix in the paths of all nodes in the tree
+// rooted at node, oldPrefix must be a prefix of every path in the tree
+func replacePathPrefix(node *FolderTreeNode, oldPrefix, newPrefix string) {
+	oldPrefix = strings.Trim(oldPrefix, ".")
 	newPrefix = strings.Trim(newPrefix, ".")
 
 	stack := []*FolderTreeNode{node}
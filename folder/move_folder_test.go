@@ -2,6 +2,7 @@ package folder_test
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/georgechieng-sc/interns-2022/folder"
@@ -125,7 +126,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 				{"charlie", firstOrgId, "alpha.charlie"},
 			},
 			[]folder.Folder{},
-			errors.New("Cannot move a folder to a child of itself"),
+			folder.ErrMoveIntoDescendant,
 		},
 		{
 			"attempt move to self",
@@ -135,7 +136,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 				{"alpha", firstOrgId, "alpha"},
 			},
 			[]folder.Folder{},
-			errors.New("Cannot move a folder to itself"),
+			folder.ErrMoveToSelf,
 		},
 		{
 			"attempt move to different organization",
@@ -146,7 +147,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 				{"bravo", secondOrgId, "bravo"},
 			},
 			[]folder.Folder{},
-			errors.New("Cannot move a folder to a different organization"),
+			folder.ErrCrossOrgMove,
 		},
 		{
 			"attempt move non-existant source folder",
@@ -157,7 +158,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 				{"bravo", firstOrgId, "bravo"},
 			},
 			[]folder.Folder{},
-			errors.New("Source folder does not exist"),
+			folder.ErrSourceNotFound,
 		},
 		{
 			"attempt move to non-existant destination folder",
@@ -168,7 +169,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 				{"bravo", firstOrgId, "bravo"},
 			},
 			[]folder.Folder{},
-			errors.New("Destination folder does not exist"),
+			folder.ErrDestinationNotFound,
 		},
 		{
 			"deeper trees, more organizations",
@@ -284,6 +285,36 @@ func Test_folder_MoveFolder(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"destination name exists in several organizations",
+			"charlie",
+			"archive",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "alpha.archive"},
+				{"charlie", firstOrgId, "charlie"},
+				{"archive", secondOrgId, "archive"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "alpha.archive"},
+				{"charlie", firstOrgId, "alpha.archive.charlie"},
+				{"archive", secondOrgId, "archive"},
+			},
+			nil,
+		},
+		{
+			"attempt move source name in several organizations",
+			"archive",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "archive"},
+				{"archive", secondOrgId, "archive"},
+			},
+			[]folder.Folder{},
+			folder.ErrSourceAmbiguous,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -296,6 +327,415 @@ func Test_folder_MoveFolder(t *testing.T) {
 	}
 }
 
+func Test_folder_MoveFolderInOrg(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		orgID   uuid.UUID
+		target  string
+		dst     string
+		folders []folder.Folder
+		want    []folder.Folder
+		err     error
+	}{
+		{
+			"same names in several organizations",
+			secondOrgId,
+			"archive",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "archive"},
+				{"alpha", secondOrgId, "alpha"},
+				{"archive", secondOrgId, "archive"},
+				{"bravo", secondOrgId, "archive.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"archive", firstOrgId, "archive"},
+				{"alpha", secondOrgId, "alpha"},
+				{"archive", secondOrgId, "alpha.archive"},
+				{"bravo", secondOrgId, "alpha.archive.bravo"},
+			},
+			nil,
+		},
+		{
+			"attempt move source in another organization",
+			secondOrgId,
+			"alpha",
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrSourceNotFound,
+		},
+		{
+			"attempt move destination in another organization",
+			firstOrgId,
+			"alpha",
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrDestinationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.MoveFolderInOrg(tt.orgID, tt.target, tt.dst)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_MoveFolderByPath(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	// bravo is used under two parents
+	folders := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "delta"},
+		{"bravo", firstOrgId, "delta.bravo"},
+		{"alpha", secondOrgId, "alpha"},
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name  string
+		orgID uuid.UUID
+		src   string
+		dst   string
+		want  []folder.Folder
+		err   error
+	}{
+		{
+			"shared name to top level folder",
+			firstOrgId,
+			"alpha.bravo.charlie",
+			"delta.bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "delta.bravo.charlie"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "delta.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"subtree with shared name",
+			firstOrgId,
+			"alpha.bravo",
+			"delta",
+			[]folder.Folder{},
+			folder.ErrFolderExists,
+		},
+		{
+			"subtree under another parent",
+			firstOrgId,
+			"delta",
+			"alpha.bravo.charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+				{"bravo", firstOrgId, "alpha.bravo.charlie.delta.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"empty destination moves to the top level",
+			firstOrgId,
+			"alpha.bravo.charlie",
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "charlie"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "delta.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"shared name to the top level",
+			firstOrgId,
+			"delta.bravo",
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+
+		{
+			"into descendant sharing no name",
+			firstOrgId,
+			"alpha",
+			"alpha.bravo.charlie",
+			[]folder.Folder{},
+			folder.ErrMoveIntoDescendant,
+		},
+		{
+			"into a folder sharing a name with the source",
+			firstOrgId,
+			"delta.bravo",
+			"alpha.bravo.charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "alpha.bravo.charlie.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"to itself",
+			firstOrgId,
+			"alpha.bravo",
+			"alpha.bravo",
+			[]folder.Folder{},
+			folder.ErrMoveToSelf,
+		},
+		{
+			"bare name of a nested source",
+			firstOrgId,
+			"charlie",
+			"delta",
+			[]folder.Folder{},
+			folder.ErrSourceNotFound,
+		},
+		{
+			"destination in another organization",
+			secondOrgId,
+			"alpha",
+			"delta",
+			[]folder.Folder{},
+			folder.ErrDestinationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(slices.Clone(folders))
+			got, err := f.MoveFolderByPath(tt.orgID, tt.src, tt.dst)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+
+			var moveErr *folder.MoveError
+			if tt.err != nil && (!errors.As(err, &moveErr) || moveErr.Src != tt.src || moveErr.Dst != tt.dst) {
+				t.Fatalf("MoveFolderByPath error does not name the paths. got=%v", err)
+			}
+		})
+	}
+
+	t.Run("name taken at the top level", func(t *testing.T) {
+		f := folder.NewDriver([]folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"bravo", firstOrgId, "bravo"},
+		})
+		got, err := f.MoveFolderByPath(firstOrgId, "alpha.bravo", "")
+
+		testFolderResults(t, got, []folder.Folder{})
+		testFolderError(t, err, folder.ErrFolderExists)
+	})
+}
+
+func Test_folder_MoveFolder_MoveError(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", secondOrgId, "charlie"},
+	})
+
+	tests := [...]struct {
+		name string
+		move func() ([]folder.Folder, error)
+		want folder.MoveError
+	}{
+		{
+			"into descendant",
+			func() ([]folder.Folder, error) { return f.MoveFolder("alpha", "bravo") },
+			folder.MoveError{Src: "alpha", Dst: "bravo", OrgID: firstOrgId, Err: folder.ErrMoveIntoDescendant},
+		},
+		{
+			"cross organization",
+			func() ([]folder.Folder, error) { return f.MoveFolder("bravo", "charlie") },
+			folder.MoveError{Src: "bravo", Dst: "charlie", OrgID: firstOrgId, Err: folder.ErrCrossOrgMove},
+		},
+		{
+			"unknown source",
+			func() ([]folder.Folder, error) { return f.MoveFolder("invalid", "alpha") },
+			folder.MoveError{Src: "invalid", Dst: "alpha", OrgID: uuid.Nil, Err: folder.ErrSourceNotFound},
+		},
+		{
+			"unknown destination in organization",
+			func() ([]folder.Folder, error) { return f.MoveFolderInOrg(secondOrgId, "charlie", "alpha") },
+			folder.MoveError{Src: "charlie", Dst: "alpha", OrgID: secondOrgId, Err: folder.ErrDestinationNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.move()
+
+			var moveErr *folder.MoveError
+			if !errors.As(err, &moveErr) {
+				t.Fatalf("MoveFolder error is not a *MoveError. got=%v", err)
+			}
+			if *moveErr != tt.want {
+				t.Fatalf("MoveFolder error wanted=%+v. got=%+v", tt.want, *moveErr)
+			}
+		})
+	}
+}
+
+func Test_folder_MoveFolderToRoot(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		orgID   uuid.UUID
+		target  string
+		folders []folder.Folder
+		want    []folder.Folder
+		err     error
+	}{
+		{
+			"move leaf to root",
+			firstOrgId,
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "bravo"},
+			},
+			nil,
+		},
+		{
+			"move deep subtree to root",
+			firstOrgId,
+			"charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+				{"echo", firstOrgId, "alpha.bravo.charlie.delta.echo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "charlie"},
+				{"delta", firstOrgId, "charlie.delta"},
+				{"echo", firstOrgId, "charlie.delta.echo"},
+			},
+			nil,
+		},
+		{
+			"move top-level to root",
+			firstOrgId,
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			nil,
+		},
+		{
+			"attempt move folder in another organization",
+			secondOrgId,
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrSourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.MoveFolderToRoot(tt.orgID, tt.target)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_MoveFolder_RootConsistency(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "bravo"},
+		{"charlie", firstOrgId, "bravo.charlie"},
+	})
+
+	// a top-level source must leave the org roots
+	_, err := f.MoveFolder("bravo", "alpha")
+	testFolderError(t, err, nil)
+	testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+	})
+
+	// and its parent must be set so it can be moved again
+	_, err = f.MoveFolder("bravo", "charlie")
+	testFolderError(t, err, folder.ErrMoveIntoDescendant)
+	_, err = f.MoveFolderToRoot(firstOrgId, "charlie")
+	testFolderError(t, err, nil)
+	_, err = f.MoveFolder("bravo", "charlie")
+	testFolderError(t, err, nil)
+	testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"charlie", firstOrgId, "charlie"},
+		{"bravo", firstOrgId, "charlie.bravo"},
+	})
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "alpha"), nil)
+}
+
 func Test_folder_MoveFolder_Complex(t *testing.T) {
 	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
 
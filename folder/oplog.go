@@ -0,0 +1,251 @@
+package folder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/gofrs/uuid"
+)
+
+// ErrCorruptLog is returned when a record header fails its checksum, or a
+// record before the end of an OpLog fails its payload checksum. Only a record
+// that runs up to the end of the file is treated as a torn write and dropped
+// instead.
+var ErrCorruptLog = errors.New("Operation log is corrupt")
+
+// OpKind names the driver mutation an Op records.
+type OpKind string
+
+const (
+	OpMove       OpKind = "move"
+	OpMoveInOrg  OpKind = "move_in_org"
+	OpMoveToRoot OpKind = "move_to_root"
+	OpMoveByPath OpKind = "move_by_path"
+	OpCreate     OpKind = "create"
+	OpDelete     OpKind = "delete"
+	OpRename     OpKind = "rename"
+	OpTransfer   OpKind = "transfer"
+	OpCopy       OpKind = "copy"
+	OpSnapshot   OpKind = "snapshot"
+)
+
+// bytes of length and checksums in front of every record payload
+const recordHeadLen = 12
+
+// Op is a single entry in an OpLog. Target holds the destination, parent or
+// new name depending on Kind, and path ops hold paths in Name and Target.
+// Copies holds the names picked for a copy in the order CopyFolder visits the
+// subtree. Folders holds the full folder set of a snapshot.
+type Op struct {
+	Kind      OpKind    `json:"kind"`
+	OrgID     uuid.UUID `json:"org_id"`
+	Name      string    `json:"name,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	DstOrgID  uuid.UUID `json:"dst_org_id"`
+	Recursive bool      `json:"recursive,omitempty"`
+	Copies    []string  `json:"copies,omitempty"`
+	Folders   []Folder  `json:"folders,omitempty"`
+}
+
+// applies op to driver, snapshots can only be applied by NewDriverWithLog
+func (op Op) apply(driver IDriver) ([]Folder, error) {
+	switch op.Kind {
+	case OpMove:
+		return driver.MoveFolder(op.Name, op.Target)
+	case OpMoveInOrg:
+		return driver.MoveFolderInOrg(op.OrgID, op.Name, op.Target)
+	case OpMoveToRoot:
+		return driver.MoveFolderToRoot(op.OrgID, op.Name)
+	case OpMoveByPath:
+		return driver.MoveFolderByPath(op.OrgID, op.Name, op.Target)
+	case OpCreate:
+		return driver.CreateFolder(op.OrgID, op.Name, op.Target)
+	case OpDelete:
+		return driver.DeleteFolder(op.OrgID, op.Name, op.Recursive)
+	case OpRename:
+		return driver.RenameFolder(op.OrgID, op.Name, op.Target)
+	case OpTransfer:
+		return driver.TransferFolder(op.Name, op.DstOrgID, op.Target)
+	case OpCopy:
+		// a retry means the planned name is taken, so it keeps getting the
+		// same name until CopyFolder gives up
+		next := 0
+		return driver.CopyFolder(op.OrgID, op.Name, op.Target, func(name string, attempt int) string {
+			if attempt == 1 {
+				next++
+			}
+			if next > len(op.Copies) {
+				return ""
+			}
+			return op.Copies[next-1]
+		})
+	}
+	return []Folder{}, errors.New("Unknown operation kind " + string(op.Kind))
+}
+
+// OpLog is an append-only file of checksummed Op records. Each record is a
+// little-endian uint32 payload length, a uint32 CRC-32 (IEEE) of the payload,
+// a uint32 CRC-32 (IEEE) of the first eight header bytes and the JSON encoded
+// Op. The header checksum means a damaged length can't pass for a torn
+// write.
+type OpLog struct {
+	path string
+	file *os.File
+	size int64
+}
+
+// opens the log at path, creating it if needed, and returns the ops it holds
+// a torn record at the end of the file is dropped and truncated away so new
+// records are appended after the last good one
+func OpenOpLog(path string) (*OpLog, []Op, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ops, end, err := readOps(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &OpLog{path: path, file: file, size: end}, ops, nil
+}
+
+// reads records from the start of file, returning the ops and the offset just
+// past the last good record
+// a record is only torn when the file ends inside it, a bad header anywhere
+// is corruption as its length can't be trusted to say where the record ends
+func readOps(file *os.File) ([]Op, int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	size := info.Size()
+
+	var ops []Op
+	var offset int64
+	reader := bufio.NewReader(io.NewSectionReader(file, 0, size))
+	head := make([]byte, recordHeadLen)
+	for {
+		if _, err := io.ReadFull(reader, head); err != nil {
+			// clean end of file, or a torn header
+			return ops, offset, nil
+		}
+		if crc32.ChecksumIEEE(head[0:8]) != binary.LittleEndian.Uint32(head[8:12]) {
+			return nil, 0, ErrCorruptLog
+		}
+		length := int64(binary.LittleEndian.Uint32(head[0:4]))
+		sum := binary.LittleEndian.Uint32(head[4:8])
+		recordEnd := offset + recordHeadLen + length
+		if recordEnd > size {
+			// torn payload
+			return ops, offset, nil
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil, 0, err
+		}
+
+		var op Op
+		if crc32.ChecksumIEEE(payload) != sum || json.Unmarshal(payload, &op) != nil {
+			if recordEnd == size {
+				// torn write of the final record
+				return ops, offset, nil
+			}
+			return nil, 0, ErrCorruptLog
+		}
+
+		ops = append(ops, op)
+		offset = recordEnd
+	}
+}
+
+func encodeOp(op Op) ([]byte, error) {
+	payload, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	record := make([]byte, recordHeadLen, recordHeadLen+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	binary.LittleEndian.PutUint32(record[8:12], crc32.ChecksumIEEE(record[0:8]))
+	return append(record, payload...), nil
+}
+
+// writes op to the end of the log and syncs it to disk
+// a failed write is cut back off so later records stay readable
+func (l *OpLog) Append(op Op) error {
+	record, err := encodeOp(op)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(record); err != nil {
+		l.truncate(l.size)
+		return err
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	l.size += int64(len(record))
+	return nil
+}
+
+// cuts the log back to size bytes, dropping every record appended after that
+func (l *OpLog) truncate(size int64) error {
+	if err := l.file.Truncate(size); err != nil {
+		return err
+	}
+	if _, err := l.file.Seek(size, io.SeekStart); err != nil {
+		return err
+	}
+	l.size = size
+	return l.file.Sync()
+}
+
+// atomically replaces the whole log with ops
+// the new log is written next to the old one and renamed over it
+func (l *OpLog) Rewrite(ops []Op) error {
+	var size int64
+	file, err := replaceFile(l.path, func(w io.Writer) error {
+		for _, op := range ops {
+			record, err := encodeOp(op)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(record); err != nil {
+				return err
+			}
+			size += int64(len(record))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// keep appending to the new file
+	l.file.Close()
+	l.file = file
+	l.size = size
+	return nil
+}
+
+func (l *OpLog) Close() error {
+	return l.file.Close()
+}
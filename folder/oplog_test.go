@@ -0,0 +1,385 @@
+package folder_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+func testOps(t *testing.T, got []folder.Op, want []folder.Op) {
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Fatalf("OpLog ops do not match expected:\n%s", diff[0])
+	}
+}
+
+func Test_folder_OpLog(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	ops := []folder.Op{
+		{Kind: folder.OpCreate, OrgID: firstOrgId, Name: "alpha"},
+		{Kind: folder.OpCreate, OrgID: firstOrgId, Name: "bravo", Target: "alpha"},
+		{Kind: folder.OpDelete, OrgID: firstOrgId, Name: "bravo", Recursive: true},
+	}
+
+	// writes ops to a fresh log and returns its path
+	writeLog := func(t *testing.T) string {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		testOps(t, recovered, nil)
+		for _, op := range ops {
+			testFolderError(t, log.Append(op), nil)
+		}
+		testFolderError(t, log.Close(), nil)
+		return path
+	}
+
+	t.Parallel()
+	t.Run("reopen returns appended ops", func(t *testing.T) {
+		path := writeLog(t)
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, ops)
+	})
+
+	t.Run("torn final record is dropped", func(t *testing.T) {
+		path := writeLog(t)
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Truncate(path, info.Size()-3); err != nil {
+			t.Fatal(err)
+		}
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		testOps(t, recovered, ops[:2])
+
+		// new records go after the last good one
+		testFolderError(t, log.Append(ops[2]), nil)
+		testFolderError(t, log.Close(), nil)
+		log, recovered, err = folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, ops)
+	})
+
+	t.Run("torn header is dropped", func(t *testing.T) {
+		path := writeLog(t)
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte{0x10, 0x00})
+		file.Close()
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, ops)
+	})
+
+	t.Run("bad checksum on final record is dropped", func(t *testing.T) {
+		path := writeLog(t)
+		flipLastByte(t, path)
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, ops[:2])
+	})
+
+	t.Run("bad checksum before final record", func(t *testing.T) {
+		path := writeLog(t)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// first payload byte of the first record
+		data[12] ^= 0xff
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = folder.OpenOpLog(path)
+		testFolderError(t, err, folder.ErrCorruptLog)
+	})
+
+	t.Run("bad length before final record", func(t *testing.T) {
+		path := writeLog(t)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// send the first record's length past the end of the file
+		data[3] ^= 0x40
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = folder.OpenOpLog(path)
+		testFolderError(t, err, folder.ErrCorruptLog)
+
+		// the good records after it are left for recovery
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() != int64(len(data)) {
+			t.Fatalf("corrupt log was cut from %d to %d bytes", len(data), info.Size())
+		}
+	})
+
+	t.Run("rewrite replaces every record", func(t *testing.T) {
+		path := writeLog(t)
+		log, _, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		testFolderError(t, log.Rewrite(ops[:1]), nil)
+		testFolderError(t, log.Append(ops[2]), nil)
+		testFolderError(t, log.Close(), nil)
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, []folder.Op{ops[0], ops[2]})
+	})
+}
+
+func flipLastByte(t *testing.T, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 0xff
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_folder_NewDriverWithLog(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	snapshot := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", secondOrgId, "charlie"},
+	}
+	want := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"echo", firstOrgId, "alpha.delta.echo"},
+		{"template-delta", firstOrgId, "template-delta"},
+		{"template-echo", firstOrgId, "alpha.template-echo"},
+		{"charlie", secondOrgId, "charlie"},
+		{"bravo", secondOrgId, "charlie.bravo"},
+	}
+
+	// applies a run of mutations, including a rejected one
+	mutate := func(t *testing.T, f folder.IDriver) {
+		steps := []struct {
+			apply func() ([]folder.Folder, error)
+			err   error
+		}{
+			{func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "delta", "alpha") }, nil},
+			{func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "echo", "delta") }, nil},
+			{func() ([]folder.Folder, error) { return f.MoveFolder("echo", "invalid") }, folder.ErrDestinationNotFound},
+			{func() ([]folder.Folder, error) { return f.TransferFolder("bravo", secondOrgId, "charlie") }, nil},
+			{func() ([]folder.Folder, error) {
+				return f.CopyFolder(firstOrgId, "delta", "", func(name string, attempt int) string {
+					return "template-" + name
+				})
+			}, nil},
+			{func() ([]folder.Folder, error) {
+				return f.MoveFolderByPath(firstOrgId, "template-delta.template-echo", "alpha")
+			}, nil},
+		}
+		for _, step := range steps {
+			_, err := step.apply()
+			testFolderError(t, err, step.err)
+		}
+	}
+
+	// every folder the driver holds across both orgs
+	allFolders := func(f folder.IDriver) []folder.Folder {
+		return append(f.GetFoldersByOrgID(firstOrgId), f.GetFoldersByOrgID(secondOrgId)...)
+	}
+
+	t.Parallel()
+	t.Run("invalid snapshot is refused", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		_, err := folder.NewDriverWithLog([]folder.Folder{{"bravo", firstOrgId, "alpha.bravo"}}, path)
+		testFolderError(t, err, folder.ErrInvalidFolders)
+	})
+
+	t.Run("replay on top of snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		f, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		mutate(t, f)
+		testFolderResults(t, allFolders(f), want)
+		testFolderError(t, f.Close(), nil)
+
+		// simulate a crash part way through the next write
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte{0xff, 0x00, 0x00, 0x00, 0x01, 0x02})
+		file.Close()
+
+		recovered, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		defer recovered.Close()
+		testFolderResults(t, allFolders(recovered), want)
+	})
+
+	t.Run("compaction writes a fresh snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		f, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		mutate(t, f)
+		testFolderError(t, f.Compact(), nil)
+
+		_, err = f.RenameFolder(firstOrgId, "echo", "foxtrot")
+		testFolderError(t, err, nil)
+		testFolderError(t, f.Close(), nil)
+
+		// the compacted log no longer needs the original snapshot
+		recovered, err := folder.NewDriverWithLog(nil, path)
+		testFolderError(t, err, nil)
+		defer recovered.Close()
+		testFolderResults(t, allFolders(recovered), []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"delta", firstOrgId, "alpha.delta"},
+			{"foxtrot", firstOrgId, "alpha.delta.foxtrot"},
+			{"template-delta", firstOrgId, "template-delta"},
+			{"template-echo", firstOrgId, "alpha.template-echo"},
+			{"charlie", secondOrgId, "charlie"},
+			{"bravo", secondOrgId, "charlie.bravo"},
+		})
+	})
+}
+
+func Test_folder_LoggedDriver_RejectedOps(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	snapshot := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+	}
+
+	t.Parallel()
+	t.Run("rejected ops are not logged", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		f, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		_, err = f.MoveFolder("alpha", "bravo")
+		testFolderError(t, err, folder.ErrMoveIntoDescendant)
+		_, err = f.CreateFolder(firstOrgId, "charlie", "missing")
+		testFolderError(t, err, folder.ErrParentNotFound)
+		_, err = f.CreateFolder(firstOrgId, "charlie", "alpha")
+		testFolderError(t, err, nil)
+		testFolderError(t, f.Close(), nil)
+
+		log, recovered, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		defer log.Close()
+		testOps(t, recovered, []folder.Op{
+			{Kind: folder.OpCreate, OrgID: firstOrgId, Name: "charlie", Target: "alpha"},
+		})
+	})
+
+	t.Run("rejected op left by a crash is skipped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		log, _, err := folder.OpenOpLog(path)
+		testFolderError(t, err, nil)
+		testFolderError(t, log.Append(folder.Op{Kind: folder.OpCreate, OrgID: firstOrgId, Name: "charlie", Target: "missing"}), nil)
+		testFolderError(t, log.Append(folder.Op{Kind: folder.OpCreate, OrgID: firstOrgId, Name: "delta", Target: "alpha"}), nil)
+		testFolderError(t, log.Close(), nil)
+
+		f, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		defer f.Close()
+		testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"delta", firstOrgId, "alpha.delta"},
+		})
+	})
+
+	t.Run("failed append leaves the driver unchanged", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.log")
+		f, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		_, err = f.CreateFolder(firstOrgId, "charlie", "alpha")
+		testFolderError(t, err, nil)
+
+		// appends fail once the log file is closed
+		testFolderError(t, f.Close(), nil)
+		_, err = f.CreateFolder(firstOrgId, "delta", "charlie")
+		if err == nil {
+			t.Fatal("CreateFolder succeeded without a log to write to")
+		}
+		want := []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"charlie", firstOrgId, "alpha.charlie"},
+		}
+		testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), want)
+
+		reopened, err := folder.NewDriverWithLog(snapshot, path)
+		testFolderError(t, err, nil)
+		defer reopened.Close()
+		testFolderResults(t, reopened.GetFoldersByOrgID(firstOrgId), want)
+	})
+}
+
+func Test_folder_LoggedDriver_CopySharedNames(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	// x is used twice inside the copied subtree
+	snapshot := []folder.Folder{
+		{"a", firstOrgId, "a"},
+		{"x", firstOrgId, "a.x"},
+		{"b", firstOrgId, "a.b"},
+		{"x", firstOrgId, "a.b.x"},
+	}
+	want := []folder.Folder{
+		{"a", firstOrgId, "a"},
+		{"x", firstOrgId, "a.x"},
+		{"b", firstOrgId, "a.b"},
+		{"x", firstOrgId, "a.b.x"},
+		{"a-copy", firstOrgId, "a-copy"},
+		{"b-copy", firstOrgId, "a-copy.b-copy"},
+		{"x-copy", firstOrgId, "a-copy.b-copy.x-copy"},
+		{"x-copy-2", firstOrgId, "a-copy.x-copy-2"},
+	}
+
+	path := filepath.Join(t.TempDir(), "folders.log")
+	f, err := folder.NewDriverWithLog(snapshot, path)
+	testFolderError(t, err, nil)
+	got, err := f.CopyFolder(firstOrgId, "a", "", nil)
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, want)
+
+	// the plain driver picks the same names
+	got, err = folder.NewDriver(slices.Clone(snapshot)).CopyFolder(firstOrgId, "a", "", nil)
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, want)
+
+	testFolderError(t, f.Close(), nil)
+	replayed, err := folder.NewDriverWithLog(snapshot, path)
+	testFolderError(t, err, nil)
+	defer replayed.Close()
+	testFolderResults(t, replayed.GetFoldersByOrgID(firstOrgId), want)
+}
@@ -0,0 +1,135 @@
+package folder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// Page is one page of a paginated listing. Folders are in the same order as
+// the unpaginated method returns them, NextCursor fetches the next page and is
+// empty on the last one.
+type Page struct {
+	Folders    []Folder `json:"folders"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// pageCursor is the decoded form of Page.NextCursor
+// a cursor only resumes the listing it came from, and only while the org is
+// unchanged since the page was read, After is the path of the last folder
+// returned
+type pageCursor struct {
+	OrgID   uuid.UUID `json:"org"`
+	Name    string    `json:"name,omitempty"`
+	Version uint64    `json:"version"`
+	After   string    `json:"after"`
+}
+
+// runs in O(limit + d k log k) where d is the depth of the cursor's folder and
+// k the most siblings of any folder on the way down to it
+func (f *driver) GetFoldersByOrgIDPage(orgID uuid.UUID, limit int, cursor string) (Page, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.page(sortedNodes(f.folderTree[orgID]), orgID, "", limit, cursor)
+}
+
+func (f *driver) GetAllChildFoldersPage(orgID uuid.UUID, name string, limit int, cursor string) (Page, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return Page{Folders: []Folder{}}, err
+	}
+	return f.page(sortedNodes(node.children), orgID, name, limit, cursor)
+}
+
+// reads the page of the listing over roots that cursor points at
+func (f *driver) page(roots []*FolderTreeNode, orgID uuid.UUID, name string, limit int, cursor string) (Page, error) {
+	if limit < 1 {
+		return Page{Folders: []Folder{}}, ErrInvalidPageSize
+	}
+
+	after := ""
+	if cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil || c.OrgID != orgID || c.Name != name || c.After == "" {
+			return Page{Folders: []Folder{}}, ErrInvalidCursor
+		}
+		if c.Version != f.versions[orgID] {
+			return Page{Folders: []Folder{}}, ErrCursorInvalidated
+		}
+		after = c.After
+	}
+
+	// one extra folder tells us whether there is another page
+	folders := collectAfter(roots, after, limit+1)
+	if len(folders) <= limit {
+		return Page{Folders: folders}, nil
+	}
+
+	folders = folders[:limit]
+	next := encodeCursor(pageCursor{
+		OrgID:   orgID,
+		Name:    name,
+		Version: f.versions[orgID],
+		After:   folders[limit-1].Paths,
+	})
+	return Page{Folders: folders, NextCursor: next}, nil
+}
+
+// returns up to n folders from the pre-order walk of roots that come after the
+// folder at path after, or from the start when after is empty
+// pre-order with siblings in name order is the same as ordering paths segment
+// by segment, so whole subtrees before after are skipped without visiting them
+func collectAfter(roots []*FolderTreeNode, after string, n int) []Folder {
+	var afterSegments []string
+	if after != "" {
+		afterSegments = strings.Split(after, ".")
+	}
+
+	folders := []Folder{}
+	stack := slices.Clone(roots)
+	slices.Reverse(stack)
+	for len(stack) > 0 && len(folders) < n {
+		curr := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		segments := strings.Split(curr.folder.Paths, ".")
+		if afterSegments != nil && slices.Compare(segments, afterSegments) <= 0 {
+			// already returned, only the folders on the way down to after can
+			// still have unread descendants
+			if len(segments) > len(afterSegments) || !slices.Equal(segments, afterSegments[:len(segments)]) {
+				continue
+			}
+		} else {
+			folders = append(folders, *curr.folder)
+		}
+
+		children := sortedNodes(curr.children)
+		for i := len(children) - 1; i >= 0; i-- {
+			stack = append(stack, children[i])
+		}
+	}
+
+	return folders
+}
+
+func encodeCursor(c pageCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(cursor string) (pageCursor, error) {
+	var c pageCursor
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(b, &c)
+	return c, err
+}
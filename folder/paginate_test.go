@@ -0,0 +1,266 @@
+package folder_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+func paginationTestFolders() []folder.Folder {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	return []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "alpha.bravo.delta"},
+		{"echo", firstOrgId, "alpha.echo"},
+		{"foxtrot", firstOrgId, "alpha.echo.foxtrot"},
+		{"golf", firstOrgId, "golf"},
+		{"hotel", firstOrgId, "golf.hotel"},
+		{"india", firstOrgId, "india"},
+		{"juliett", secondOrgId, "juliett"},
+	}
+}
+
+// reads every page of a listing, failing on any error
+func readAllPages(t *testing.T, limit int, read func(cursor string) (folder.Page, error)) ([]folder.Folder, int) {
+	t.Helper()
+
+	folders := []folder.Folder{}
+	pages := 0
+	cursor := ""
+	for {
+		page, err := read(cursor)
+		if err != nil {
+			t.Fatalf("page %d: %v", pages+1, err)
+		}
+		if len(page.Folders) > limit {
+			t.Fatalf("page %d has %d folders, limit is %d", pages+1, len(page.Folders), limit)
+		}
+		folders = append(folders, page.Folders...)
+		pages++
+		if page.NextCursor == "" {
+			return folders, pages
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func Test_folder_GetFoldersByOrgIDPage(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	for _, limit := range []int{1, 2, 3, 8, 9, 10, 100} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			f := folder.NewDriver(paginationTestFolders())
+			want := f.GetFoldersByOrgID(firstOrgId)
+
+			got, pages := readAllPages(t, limit, func(cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, limit, cursor)
+			})
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Fatalf("pages do not match GetFoldersByOrgID:\n%s", strings.Join(diff, "\n"))
+			}
+			if wantPages := max(1, (len(want)+limit-1)/limit); pages != wantPages {
+				t.Errorf("got %d pages, want %d", pages, wantPages)
+			}
+		})
+	}
+}
+
+func Test_folder_GetAllChildFoldersPage(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	for _, name := range []string{"alpha", "bravo", "charlie", "golf"} {
+		for _, limit := range []int{1, 2, 5} {
+			t.Run(fmt.Sprintf("%s limit %d", name, limit), func(t *testing.T) {
+				f := folder.NewDriver(paginationTestFolders())
+				want := f.GetAllChildFolders(firstOrgId, name)
+				if want == nil {
+					want = []folder.Folder{}
+				}
+
+				got, _ := readAllPages(t, limit, func(cursor string) (folder.Page, error) {
+					return f.GetAllChildFoldersPage(firstOrgId, name, limit, cursor)
+				})
+				if diff := deep.Equal(got, want); diff != nil {
+					t.Fatalf("pages do not match GetAllChildFolders:\n%s", strings.Join(diff, "\n"))
+				}
+			})
+		}
+	}
+}
+
+func Test_folder_Page_Errors(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name string
+		// runs between reading the first page and asking for the second
+		between func(f folder.IDriver) error
+		next    func(f folder.IDriver, cursor string) (folder.Page, error)
+		err     error
+	}{
+		{
+			"unchanged",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			nil,
+		},
+		{
+			"move in the same organization",
+			func(f folder.IDriver) error {
+				_, err := f.MoveFolder("hotel", "alpha")
+				return err
+			},
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			folder.ErrCursorInvalidated,
+		},
+		{
+			"create in the same organization",
+			func(f folder.IDriver) error {
+				_, err := f.CreateFolder(firstOrgId, "kilo", "")
+				return err
+			},
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			folder.ErrCursorInvalidated,
+		},
+		{
+			"transfer out of the organization",
+			func(f folder.IDriver) error {
+				_, err := f.TransferFolder("india", secondOrgId, "")
+				return err
+			},
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			folder.ErrCursorInvalidated,
+		},
+		{
+			"move in another organization",
+			func(f folder.IDriver) error {
+				_, err := f.CreateFolder(secondOrgId, "kilo", "juliett")
+				return err
+			},
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			nil,
+		},
+		{
+			"failed move",
+			func(f folder.IDriver) error {
+				if _, err := f.MoveFolder("alpha", "bravo"); err == nil {
+					return fmt.Errorf("move into descendant succeeded")
+				}
+				return nil
+			},
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, cursor)
+			},
+			nil,
+		},
+		{
+			"cursor from another organization",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(secondOrgId, 2, cursor)
+			},
+			folder.ErrInvalidCursor,
+		},
+		{
+			"cursor from another listing",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetAllChildFoldersPage(firstOrgId, "alpha", 2, cursor)
+			},
+			folder.ErrInvalidCursor,
+		},
+		{
+			"malformed cursor",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 2, "not a cursor")
+			},
+			folder.ErrInvalidCursor,
+		},
+		{
+			"zero limit",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetFoldersByOrgIDPage(firstOrgId, 0, cursor)
+			},
+			folder.ErrInvalidPageSize,
+		},
+		{
+			"missing folder",
+			func(f folder.IDriver) error { return nil },
+			func(f folder.IDriver, cursor string) (folder.Page, error) {
+				return f.GetAllChildFoldersPage(firstOrgId, "zulu", 2, "")
+			},
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(paginationTestFolders())
+			first, err := f.GetFoldersByOrgIDPage(firstOrgId, 2, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.between(f); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = tt.next(f, first.NextCursor)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_Page_LargeOrg(t *testing.T) {
+	orgId := uuid.FromStringOrNil(FirstOrgID)
+
+	// 20 roots with 20 children each with 5 children of their own
+	var folders []folder.Folder
+	for i := range 20 {
+		root := fmt.Sprintf("root%02d", i)
+		folders = append(folders, folder.Folder{Name: root, OrgId: orgId, Paths: root})
+		for j := range 20 {
+			child := fmt.Sprintf("%s-child%02d", root, j)
+			folders = append(folders, folder.Folder{Name: child, OrgId: orgId, Paths: root + "." + child})
+			for k := range 5 {
+				leaf := fmt.Sprintf("%s-leaf%d", child, k)
+				folders = append(folders, folder.Folder{Name: leaf, OrgId: orgId, Paths: root + "." + child + "." + leaf})
+			}
+		}
+	}
+
+	t.Parallel()
+	f := folder.NewDriver(slices.Clone(folders))
+	got, _ := readAllPages(t, 37, func(cursor string) (folder.Page, error) {
+		return f.GetFoldersByOrgIDPage(orgId, 37, cursor)
+	})
+	if diff := deep.Equal(got, f.GetFoldersByOrgID(orgId)); diff != nil {
+		t.Fatalf("pages do not match GetFoldersByOrgID:\n%s", strings.Join(diff, "\n"))
+	}
+	if len(got) != len(folders) {
+		t.Errorf("got %d folders, want %d", len(got), len(folders))
+	}
+}
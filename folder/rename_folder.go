@@ -0,0 +1,45 @@
+package folder
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// renames Folder oldName in orgID to newName
+// runs in O(k) in the size of the subtree rooted at oldName due to path updates
+func (f *driver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !validFolderName(newName) {
+		return []Folder{}, ErrInvalidFolderName
+	}
+
+	node, err := f.find(orgID, oldName)
+	if err != nil {
+		return []Folder{}, err
+	}
+	if oldName == newName {
+		return f.allFolders(), nil
+	}
+	if f.nameInUse(orgID, newName) {
+		return []Folder{}, ErrFolderExists
+	}
+
+	newPath := newName
+	if node.parent != nil {
+		newPath = node.parent.folder.Paths + "." + newName
+	}
+
+	// re-key the node in its siblings and in the org lookup
+	parent := node.parent
+	f.detach(node)
+	f.unindex(node)
+	node.folder.Name = newName
+	f.index(node)
+	f.attach(node, parent)
+
+	// update paths
+	replacePathPrefix(node, node.folder.Paths, newPath)
+
+	return f.allFolders(), nil
+}
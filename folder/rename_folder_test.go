@@ -0,0 +1,179 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_RenameFolder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		orgID   uuid.UUID
+		oldName string
+		newName string
+		folders []folder.Folder
+		want    []folder.Folder
+		err     error
+	}{
+		{
+			"rename top-level leaf",
+			firstOrgId,
+			"alpha",
+			"zulu",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{
+				{"zulu", firstOrgId, "zulu"},
+			},
+			nil,
+		},
+		{
+			"rename top-level with descendants",
+			firstOrgId,
+			"alpha",
+			"zulu",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			[]folder.Folder{
+				{"zulu", firstOrgId, "zulu"},
+				{"bravo", firstOrgId, "zulu.bravo"},
+				{"charlie", firstOrgId, "zulu.bravo.charlie"},
+				{"delta", firstOrgId, "zulu.delta"},
+			},
+			nil,
+		},
+		{
+			"rename middle segment",
+			firstOrgId,
+			"bravo",
+			"br",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"bravo-2", firstOrgId, "alpha.bravo.charlie.bravo-2"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"br", firstOrgId, "alpha.br"},
+				{"charlie", firstOrgId, "alpha.br.charlie"},
+				{"bravo-2", firstOrgId, "alpha.br.charlie.bravo-2"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			nil,
+		},
+		{
+			"rename to name used by another organization",
+			firstOrgId,
+			"alpha",
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "bravo"},
+			},
+			[]folder.Folder{
+				{"bravo", firstOrgId, "bravo"},
+				{"bravo", secondOrgId, "bravo"},
+			},
+			nil,
+		},
+		{
+			"rename to same name",
+			firstOrgId,
+			"alpha",
+			"alpha",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"attempt rename to existing name",
+			firstOrgId,
+			"bravo",
+			"charlie",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "charlie"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderExists,
+		},
+		{
+			"attempt rename to name containing separator",
+			firstOrgId,
+			"alpha",
+			"alpha.bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrInvalidFolderName,
+		},
+		{
+			"attempt rename non-existant folder",
+			firstOrgId,
+			"invalid",
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.RenameFolder(tt.orgID, tt.oldName, tt.newName)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_RenameFolder_ThenLookup(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"delta", firstOrgId, "delta"},
+	})
+
+	_, err := f.RenameFolder(firstOrgId, "bravo", "echo")
+	testFolderError(t, err, nil)
+
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "echo"), []folder.Folder{
+		{"charlie", firstOrgId, "alpha.echo.charlie"},
+	})
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "bravo"), nil)
+
+	got, err := f.MoveFolder("charlie", "delta")
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"echo", firstOrgId, "alpha.echo"},
+		{"charlie", firstOrgId, "delta.charlie"},
+		{"delta", firstOrgId, "delta"},
+	})
+}
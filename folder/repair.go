@@ -0,0 +1,390 @@
+package folder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// the lost and found folder RepairOptions users usually want
+const DefaultLostAndFound = "lost+found"
+
+// RepairKind names a fix made by RepairFolders.
+type RepairKind string
+
+const (
+	// Name was invalid and was replaced with the last segment of Paths.
+	RepairFixedName RepairKind = "fixed_name"
+	// Paths had empty segments or a last segment other than Name.
+	RepairFixedPath RepairKind = "fixed_path"
+	// Paths changed because an ancestor was fixed, moved or renamed.
+	RepairUpdatedPath RepairKind = "updated_path"
+	// The folder had nothing usable, or repeated another folder's path.
+	RepairDropped RepairKind = "dropped"
+	// A missing ancestor or the lost and found folder was created.
+	RepairAddedFolder RepairKind = "added_folder"
+	// An orphan was moved under the lost and found folder.
+	RepairReparented RepairKind = "reparented"
+	// A duplicate name was replaced with a free one.
+	RepairRenamed RepairKind = "renamed"
+)
+
+// Repair is one entry in the change log RepairFolders returns. Index is the
+// folder's position in the slice being repaired, or -1 for added folders.
+// Before is zero for added folders and After is zero for dropped ones.
+type Repair struct {
+	Kind   RepairKind
+	Index  int
+	Before Folder
+	After  Folder
+}
+
+func (r Repair) String() string {
+	subject := fmt.Sprintf("folder %d", r.Index)
+	if r.Index < 0 {
+		subject = "added folder"
+	}
+
+	switch r.Kind {
+	case RepairAddedFolder:
+		return fmt.Sprintf("%s: %q in org %s", r.Kind, r.After.Paths, r.After.OrgId)
+	case RepairDropped:
+		return fmt.Sprintf("%s: %s: %q (path %q) in org %s", subject, r.Kind, r.Before.Name, r.Before.Paths, r.Before.OrgId)
+	}
+	return fmt.Sprintf("%s: %s: %q (path %q) -> %q (path %q) in org %s",
+		subject, r.Kind, r.Before.Name, r.Before.Paths, r.After.Name, r.After.Paths, r.After.OrgId)
+}
+
+// RepairOptions controls how RepairFolders fixes orphans and duplicates.
+// Orphans get their missing ancestors created unless LostAndFound is set, in
+// which case they are moved under a top level folder with that name. NameFn
+// renames duplicates and defaults to NumberedNames when nil.
+type RepairOptions struct {
+	LostAndFound string
+	NameFn       NameFunc
+}
+
+// default NameFunc for duplicates, they are named name-2, name-3...
+func NumberedNames(name string, attempt int) string {
+	return fmt.Sprintf("%s-%d", name, attempt+1)
+}
+
+// builds a driver from folders after fixing them with RepairFolders, returning
+// the change log alongside it
+func NewDriverWithRepair(folders []Folder, opts RepairOptions) (IDriver, []Repair, error) {
+	repaired, repairs, err := RepairFolders(folders, opts)
+	if err != nil {
+		return nil, repairs, err
+	}
+	driver, err := NewDriverWithValidation(repaired)
+	return driver, repairs, err
+}
+
+// one folder being repaired, index is its position in the input or -1
+type repairEntry struct {
+	folder  Folder
+	index   int
+	dropped bool
+}
+
+// a folder's position in its org's tree
+type orgPath struct {
+	orgID uuid.UUID
+	path  string
+}
+
+// a name in an org, renameDuplicates keeps names unique
+type orgName struct {
+	orgID uuid.UUID
+	name  string
+}
+
+type repairer struct {
+	entries []*repairEntry
+	repairs []Repair
+	opts    RepairOptions
+}
+
+// returns a copy of folders that passes ValidateFolders and a log of every
+// change made to get there
+// fixes are made in passes, each building on the last:
+//   - empty path segments are dropped, invalid names are taken from the path
+//     and paths are made to end in the folder's name
+//   - folders repeating the path of an earlier folder in the same org are
+//     dropped
+//   - later folders with a name already used in their org are renamed, names
+//     may repeat under different parents but are made unique so every folder
+//     can be reached by the name based methods
+//   - orphans get their missing ancestors, or are moved under LostAndFound,
+//     and added ancestors that clash with an existing name are renamed
+//
+// descendants follow any folder whose path changes
+// the input isn't modified, the output keeps its order with added folders at
+// the end
+// runs in O(n f) where f is the number of fixes, as each fix scans for the
+// descendants of the folder it changed
+func RepairFolders(folders []Folder, opts RepairOptions) ([]Folder, []Repair, error) {
+	if opts.NameFn == nil {
+		opts.NameFn = NumberedNames
+	}
+	if opts.LostAndFound != "" && !validFolderName(opts.LostAndFound) {
+		return nil, nil, ErrInvalidFolderName
+	}
+
+	r := &repairer{opts: opts}
+	for i, folder := range folders {
+		r.entries = append(r.entries, &repairEntry{folder: folder, index: i})
+	}
+
+	r.fixNamesAndPaths()
+	r.dropRepeatedPaths()
+	if err := r.renameDuplicates(); err != nil {
+		return nil, r.repairs, err
+	}
+	// names are unique now, so orphans can't collide under LostAndFound
+	r.fixOrphans()
+	if err := r.renameDuplicates(); err != nil {
+		return nil, r.repairs, err
+	}
+
+	repaired := []Folder{}
+	for _, entry := range r.entries {
+		if !entry.dropped {
+			repaired = append(repaired, entry.folder)
+		}
+	}
+	return repaired, r.repairs, nil
+}
+
+func (r *repairer) log(kind RepairKind, entry *repairEntry, before Folder) {
+	r.repairs = append(r.repairs, Repair{kind, entry.index, before, entry.folder})
+}
+
+func (r *repairer) fixNamesAndPaths() {
+	for _, entry := range r.entries {
+		before := entry.folder
+		segments := slices.DeleteFunc(strings.Split(entry.folder.Paths, "."), func(s string) bool {
+			return s == ""
+		})
+
+		if len(segments) == 0 {
+			if !validFolderName(entry.folder.Name) {
+				entry.dropped = true
+				r.repairs = append(r.repairs, Repair{RepairDropped, entry.index, before, Folder{}})
+				continue
+			}
+			segments = []string{entry.folder.Name}
+		}
+
+		if !validFolderName(entry.folder.Name) {
+			entry.folder.Name = segments[len(segments)-1]
+			r.log(RepairFixedName, entry, before)
+		}
+
+		segments[len(segments)-1] = entry.folder.Name
+		if path := strings.Join(segments, "."); path != entry.folder.Paths {
+			r.setPath(entry, path, RepairFixedPath)
+		}
+	}
+}
+
+// keeps the first folder at each path in each org
+func (r *repairer) dropRepeatedPaths() {
+	seen := make(map[orgPath]struct{})
+	for _, entry := range r.live() {
+		key := orgPath{entry.folder.OrgId, entry.folder.Paths}
+		if _, found := seen[key]; found {
+			entry.dropped = true
+			r.repairs = append(r.repairs, Repair{RepairDropped, entry.index, entry.folder, Folder{}})
+			continue
+		}
+		seen[key] = struct{}{}
+	}
+}
+
+// shallowest folders first, so an orphan's descendants move along with it
+// before they are checked themselves
+func (r *repairer) fixOrphans() {
+	paths := make(map[orgPath]struct{})
+	for _, entry := range r.live() {
+		paths[orgPath{entry.folder.OrgId, entry.folder.Paths}] = struct{}{}
+	}
+
+	orphans := r.live()
+	slices.SortStableFunc(orphans, func(a, b *repairEntry) int {
+		return strings.Count(a.folder.Paths, ".") - strings.Count(b.folder.Paths, ".")
+	})
+	for _, entry := range orphans {
+		orgID := entry.folder.OrgId
+		parent := parentPath(entry.folder.Paths)
+		if parent == "" {
+			continue
+		}
+		if _, found := paths[orgPath{orgID, parent}]; found {
+			continue
+		}
+
+		if r.opts.LostAndFound == "" {
+			segments := strings.Split(parent, ".")
+			for i := range segments {
+				path := strings.Join(segments[:i+1], ".")
+				if _, found := paths[orgPath{orgID, path}]; !found {
+					r.add(Folder{segments[i], orgID, path})
+					paths[orgPath{orgID, path}] = struct{}{}
+				}
+			}
+			continue
+		}
+
+		root := r.lostAndFound(entry, paths)
+		// moving the lost and found folder may have fixed this one too
+		if _, found := paths[orgPath{orgID, parentPath(entry.folder.Paths)}]; found || entry.folder.Paths == root {
+			continue
+		}
+		r.reparent(entry, root+"."+entry.folder.Name, paths)
+	}
+}
+
+// moves entry and its descendants to path and swaps their old paths in paths
+// for the new ones, so nothing left behind passes for a parent
+func (r *repairer) reparent(entry *repairEntry, path string, paths map[orgPath]struct{}) {
+	orgID, oldPath := entry.folder.OrgId, entry.folder.Paths
+	moved := r.setPath(entry, path, RepairReparented)
+	delete(paths, orgPath{orgID, oldPath})
+	paths[orgPath{orgID, path}] = struct{}{}
+	for _, other := range moved {
+		delete(paths, orgPath{orgID, oldPath + strings.TrimPrefix(other.folder.Paths, path)})
+		paths[orgPath{orgID, other.folder.Paths}] = struct{}{}
+	}
+}
+
+// returns the path of the lost and found folder for orphan's org
+// an existing one is only reused when all of its ancestors exist, an orphaned
+// one is moved up to the top level first, otherwise
+// a new one is created at the top level
+// names are unique when this runs, so a created folder only clashes with a
+// lost and found folder that couldn't be used, and is renamed along with the
+// other clashes
+func (r *repairer) lostAndFound(orphan *repairEntry, paths map[orgPath]struct{}) string {
+	orgID, name := orphan.folder.OrgId, r.opts.LostAndFound
+	for _, entry := range r.live() {
+		if entry.folder.OrgId != orgID || entry.folder.Name != name {
+			continue
+		}
+		// an orphan's descendants are missing its parent too, so they're
+		// never rooted
+		if rooted(orgID, entry.folder.Paths, paths) {
+			return entry.folder.Paths
+		}
+		if _, found := paths[orgPath{orgID, parentPath(entry.folder.Paths)}]; found {
+			break
+		}
+		r.reparent(entry, name, paths)
+		return name
+	}
+
+	r.add(Folder{name, orgID, name})
+	paths[orgPath{orgID, name}] = struct{}{}
+	return name
+}
+
+// reports whether every ancestor of path exists in orgID
+func rooted(orgID uuid.UUID, path string, paths map[orgPath]struct{}) bool {
+	for parent := parentPath(path); parent != ""; parent = parentPath(parent) {
+		if _, found := paths[orgPath{orgID, parent}]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *repairer) renameDuplicates() error {
+	used := make(map[orgName]struct{})
+	for _, entry := range r.live() {
+		used[orgName{entry.folder.OrgId, entry.folder.Name}] = struct{}{}
+	}
+
+	seen := make(map[orgName]struct{})
+	for _, entry := range r.live() {
+		orgID := entry.folder.OrgId
+		if _, found := seen[orgName{orgID, entry.folder.Name}]; !found {
+			seen[orgName{orgID, entry.folder.Name}] = struct{}{}
+			continue
+		}
+
+		name, err := freeName(entry.folder.Name, r.opts.NameFn, func(candidate string) bool {
+			_, found := used[orgName{orgID, candidate}]
+			return found
+		})
+		if err != nil {
+			return err
+		}
+		used[orgName{orgID, name}] = struct{}{}
+		seen[orgName{orgID, name}] = struct{}{}
+
+		before := entry.folder
+		entry.folder.Name = name
+		r.setPath(entry, replaceLastSegment(entry.folder.Paths, name), "")
+		r.log(RepairRenamed, entry, before)
+	}
+	return nil
+}
+
+// moves entry to path, logging the move as kind unless kind is empty, and
+// moves its descendants along with it
+// descendants are left alone while another folder in the org still owns the
+// old path
+// returns the descendants that moved
+func (r *repairer) setPath(entry *repairEntry, path string, kind RepairKind) []*repairEntry {
+	before := entry.folder
+	entry.folder.Paths = path
+	if kind != "" {
+		r.log(kind, entry, before)
+	}
+
+	oldPrefix := before.Paths + "."
+	var moved []*repairEntry
+	for _, other := range r.live() {
+		if other == entry || other.folder.OrgId != before.OrgId {
+			continue
+		}
+		if other.folder.Paths == before.Paths {
+			return nil
+		}
+		if strings.HasPrefix(other.folder.Paths, oldPrefix) {
+			moved = append(moved, other)
+		}
+	}
+
+	for _, other := range moved {
+		otherBefore := other.folder
+		other.folder.Paths = path + "." + strings.TrimPrefix(other.folder.Paths, oldPrefix)
+		r.log(RepairUpdatedPath, other, otherBefore)
+	}
+	return moved
+}
+
+func (r *repairer) add(folder Folder) {
+	entry := &repairEntry{folder: folder, index: -1}
+	r.entries = append(r.entries, entry)
+	r.repairs = append(r.repairs, Repair{RepairAddedFolder, -1, Folder{}, folder})
+}
+
+func (r *repairer) live() []*repairEntry {
+	var live []*repairEntry
+	for _, entry := range r.entries {
+		if !entry.dropped {
+			live = append(live, entry)
+		}
+	}
+	return live
+}
+
+func replaceLastSegment(path, name string) string {
+	if parent := parentPath(path); parent != "" {
+		return parent + "." + name
+	}
+	return name
+}
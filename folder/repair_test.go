@@ -0,0 +1,408 @@
+package folder_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_RepairFolders(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	lostAndFound := folder.RepairOptions{LostAndFound: folder.DefaultLostAndFound}
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		folders []folder.Folder
+		opts    folder.RepairOptions
+		want    []folder.Folder
+		repairs []folder.RepairKind
+	}{
+		{
+			"well-formed folders are unchanged",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"missing ancestors are added",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+				{"echo", firstOrgId, "alpha.bravo.charlie.delta.echo"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"delta", firstOrgId, "alpha.bravo.charlie.delta"},
+				{"echo", firstOrgId, "alpha.bravo.charlie.delta.echo"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			[]folder.RepairKind{folder.RepairAddedFolder, folder.RepairAddedFolder},
+		},
+		{
+			"added ancestors avoid existing names",
+			[]folder.Folder{
+				{"bravo", firstOrgId, "bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"bravo", firstOrgId, "bravo"},
+				{"charlie", firstOrgId, "alpha.bravo-2.charlie"},
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo-2", firstOrgId, "alpha.bravo-2"},
+			},
+			[]folder.RepairKind{
+				folder.RepairAddedFolder,
+				folder.RepairAddedFolder,
+				folder.RepairUpdatedPath,
+				folder.RepairRenamed,
+			},
+		},
+		{
+			"orphans move to lost and found",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "missing.bravo.charlie"},
+				{"delta", firstOrgId, "missing.bravo.charlie.delta"},
+				{"echo", firstOrgId, "gone.echo"},
+			},
+			lostAndFound,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "lost+found.charlie"},
+				{"delta", firstOrgId, "lost+found.charlie.delta"},
+				{"echo", firstOrgId, "lost+found.echo"},
+				{"lost+found", firstOrgId, "lost+found"},
+			},
+			[]folder.RepairKind{
+				folder.RepairAddedFolder,
+				folder.RepairReparented,
+				folder.RepairReparented,
+				folder.RepairUpdatedPath,
+			},
+		},
+		{
+			"existing lost and found is reused",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"lost+found", firstOrgId, "alpha.lost+found"},
+				{"charlie", firstOrgId, "missing.charlie"},
+			},
+			lostAndFound,
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"lost+found", firstOrgId, "alpha.lost+found"},
+				{"charlie", firstOrgId, "alpha.lost+found.charlie"},
+			},
+			[]folder.RepairKind{folder.RepairReparented},
+		},
+		{
+			"orphaned lost and found moves to the top level",
+			[]folder.Folder{
+				{"lost+found", firstOrgId, "missing.lost+found"},
+				{"charlie", firstOrgId, "missing.lost+found.charlie"},
+			},
+			lostAndFound,
+			[]folder.Folder{
+				{"lost+found", firstOrgId, "lost+found"},
+				{"charlie", firstOrgId, "lost+found.charlie"},
+			},
+			[]folder.RepairKind{folder.RepairReparented, folder.RepairUpdatedPath},
+		},
+		{
+			"lost and found under the orphan isn't reused",
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"lost+found", firstOrgId, "alpha.bravo.lost+found"},
+			},
+			lostAndFound,
+			[]folder.Folder{
+				{"bravo", firstOrgId, "lost+found-2.bravo"},
+				{"lost+found", firstOrgId, "lost+found-2.bravo.lost+found"},
+				{"lost+found-2", firstOrgId, "lost+found-2"},
+			},
+			[]folder.RepairKind{
+				folder.RepairAddedFolder,
+				folder.RepairReparented,
+				folder.RepairUpdatedPath,
+				folder.RepairUpdatedPath,
+				folder.RepairUpdatedPath,
+				folder.RepairRenamed,
+			},
+		},
+		{
+			"orphan under a moved lost and found",
+			[]folder.Folder{
+				{"lost+found", firstOrgId, "lost+found.lost+found"},
+				{"alpha", firstOrgId, "lost+found.lost+found.lost+found.alpha"},
+			},
+			lostAndFound,
+			[]folder.Folder{
+				{"lost+found", firstOrgId, "lost+found"},
+				{"alpha", firstOrgId, "lost+found.alpha"},
+			},
+			[]folder.RepairKind{
+				folder.RepairReparented,
+				folder.RepairUpdatedPath,
+				folder.RepairReparented,
+			},
+		},
+		{
+			"parent in another organization",
+			[]folder.Folder{
+				{"alpha", secondOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", secondOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.RepairKind{folder.RepairAddedFolder},
+		},
+		{
+			"last path segment is set to the name",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.wrong"},
+				{"charlie", firstOrgId, "alpha.wrong.charlie"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			[]folder.RepairKind{folder.RepairFixedPath, folder.RepairUpdatedPath},
+		},
+		{
+			"empty path segments are dropped",
+			[]folder.Folder{
+				{"alpha", firstOrgId, ".alpha"},
+				{"bravo", firstOrgId, "alpha..bravo."},
+				{"charlie", firstOrgId, ""},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "charlie"},
+			},
+			[]folder.RepairKind{folder.RepairFixedPath, folder.RepairFixedPath, folder.RepairFixedPath},
+		},
+		{
+			"invalid names are taken from the path",
+			[]folder.Folder{
+				{"", firstOrgId, "alpha"},
+				{"a.b", firstOrgId, "alpha.bravo"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.RepairKind{folder.RepairFixedName, folder.RepairFixedName},
+		},
+		{
+			"unusable folders are dropped",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"", firstOrgId, ""},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.RepairKind{folder.RepairDropped},
+		},
+		{
+			"repeated paths are dropped",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			[]folder.RepairKind{folder.RepairDropped},
+		},
+		{
+			"duplicate names are suffixed",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "delta.bravo"},
+				{"charlie", firstOrgId, "delta.bravo.charlie"},
+				{"bravo-2", firstOrgId, "bravo-2"},
+			},
+			folder.RepairOptions{},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo-3", firstOrgId, "delta.bravo-3"},
+				{"charlie", firstOrgId, "delta.bravo-3.charlie"},
+				{"bravo-2", firstOrgId, "bravo-2"},
+			},
+			[]folder.RepairKind{folder.RepairUpdatedPath, folder.RepairRenamed},
+		},
+		{
+			"custom names for duplicates",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", firstOrgId, "bravo.alpha"},
+				{"bravo", firstOrgId, "bravo"},
+			},
+			folder.RepairOptions{NameFn: func(name string, attempt int) string { return "dup-" + name }},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"dup-alpha", firstOrgId, "bravo.dup-alpha"},
+				{"bravo", firstOrgId, "bravo"},
+			},
+			[]folder.RepairKind{folder.RepairRenamed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]folder.Folder(nil), tt.folders...)
+			got, repairs, err := folder.RepairFolders(input, tt.opts)
+			testFolderError(t, err, nil)
+
+			if diff := deep.Equal(input, tt.folders); diff != nil {
+				t.Fatalf("RepairFolders changed its input:\n%s", diff)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatalf("RepairFolders output does not match expected:\n%s\ngot %v", diff, got)
+			}
+			if problems := folder.ValidateFolders(got); problems != nil {
+				t.Fatalf("repaired folders are still invalid: %v", problems)
+			}
+
+			var kinds []folder.RepairKind
+			for _, repair := range repairs {
+				kinds = append(kinds, repair.Kind)
+			}
+			if diff := deep.Equal(kinds, tt.repairs); diff != nil {
+				t.Fatalf("RepairFolders change log does not match expected:\n%s\ngot %v", diff, repairs)
+			}
+		})
+	}
+}
+
+func Test_folder_RepairFolders_Errors(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	duplicates := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"alpha", firstOrgId, "bravo.alpha"},
+		{"bravo", firstOrgId, "bravo"},
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name string
+		opts folder.RepairOptions
+		err  error
+	}{
+		{"invalid lost and found name", folder.RepairOptions{LostAndFound: "lost.found"}, folder.ErrInvalidFolderName},
+		{"invalid duplicate name", folder.RepairOptions{NameFn: func(name string, attempt int) string { return "" }}, folder.ErrInvalidFolderName},
+		{"no free duplicate name", folder.RepairOptions{NameFn: func(name string, attempt int) string { return "bravo" }}, folder.ErrNoFreeName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := folder.RepairFolders(duplicates, tt.opts)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_NewDriverWithRepair(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	f, repairs, err := folder.NewDriverWithRepair([]folder.Folder{
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+	}, folder.RepairOptions{})
+	testFolderError(t, err, nil)
+	if len(repairs) != 2 {
+		t.Errorf("got %d repairs, want 2: %v", len(repairs), repairs)
+	}
+
+	testFolderResults(t, f.GetAllChildFolders(firstOrgId, "alpha"), []folder.Folder{
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+	})
+}
+
+func Test_folder_RepairFolders_SampleData(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range []folder.RepairOptions{{}, {LostAndFound: folder.DefaultLostAndFound}} {
+		repaired, _, err := folder.RepairFolders(folder.GetAllFolders(), opts)
+		testFolderError(t, err, nil)
+		if problems := folder.ValidateFolders(repaired); problems != nil {
+			t.Fatalf("repaired sample data is still invalid: %v", problems)
+		}
+	}
+}
+
+func Test_folder_RepairFolders_RandomFolders(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	// few names and shallow paths, so orphans, clashes and lost and found
+	// folders in odd places all come up often
+	names := []string{"alpha", "bravo", "charlie", folder.DefaultLostAndFound, "lost+found-2", ""}
+	orgs := []uuid.UUID{firstOrgId, secondOrgId}
+
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for i := range 2000 {
+		var folders []folder.Folder
+		for range rnd.Intn(8) {
+			segments := make([]string, 1+rnd.Intn(4))
+			for j := range segments {
+				segments[j] = names[rnd.Intn(len(names))]
+			}
+			folders = append(folders, folder.Folder{
+				Name:  segments[len(segments)-1],
+				OrgId: orgs[rnd.Intn(len(orgs))],
+				Paths: strings.Join(segments, "."),
+			})
+		}
+
+		for _, opts := range []folder.RepairOptions{{}, {LostAndFound: folder.DefaultLostAndFound}} {
+			t.Run(fmt.Sprintf("%d/%q", i, opts.LostAndFound), func(t *testing.T) {
+				repaired, _, err := folder.RepairFolders(folders, opts)
+				testFolderError(t, err, nil)
+				if problems := folder.ValidateFolders(repaired); problems != nil {
+					t.Fatalf("repaired folders are still invalid: %v\ninput %v\ngot %v", problems, folders, repaired)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package folder
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// FolderKey identifies a stored folder, paths are unique within an org while
+// names can repeat under different parents.
+type FolderKey struct {
+	OrgID uuid.UUID
+	Paths string
+}
+
+// Repository stores folders as individual (name, org_id, paths) rows keyed by
+// org and path.
+type Repository interface {
+	// LoadFolders returns every stored folder.
+	LoadFolders() ([]Folder, error)
+	// Commit writes put and removes del in a single transaction.
+	Commit(put []Folder, del []FolderKey) error
+	Close() error
+}
+
+// builds a driver from the folders in repo and writes every successful
+// mutation through to repo, only rows that changed are committed
+// rows are keyed by path, so a folder whose path changes is deleted under the
+// old path and put under the new one
+// stored folders that fail ValidateFolders are refused with a *ValidationError
+func NewRepositoryDriver(repo Repository) (IDriver, error) {
+	folders, err := repo.LoadFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[FolderKey]string, len(folders))
+	for _, folder := range folders {
+		stored[FolderKey{folder.OrgId, folder.Paths}] = folder.Name
+	}
+
+	persist := func(folders []Folder) error {
+		var put []Folder
+		current := make(map[FolderKey]string, len(folders))
+		for _, folder := range folders {
+			key := FolderKey{folder.OrgId, folder.Paths}
+			current[key] = folder.Name
+			if name, found := stored[key]; !found || name != folder.Name {
+				put = append(put, folder)
+			}
+		}
+
+		var del []FolderKey
+		for key := range stored {
+			if _, found := current[key]; !found {
+				del = append(del, key)
+			}
+		}
+
+		if len(put) == 0 && len(del) == 0 {
+			return nil
+		}
+		if err := repo.Commit(put, del); err != nil {
+			return err
+		}
+		stored = current
+		return nil
+	}
+
+	driver, err := NewDriverWithValidation(folders)
+	if err != nil {
+		return nil, err
+	}
+	return newSyncedDriver(driver, persist), nil
+}
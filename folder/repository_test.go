@@ -0,0 +1,183 @@
+package folder_test
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// Repository that keeps every commit for inspection
+type recordingRepository struct {
+	folders []folder.Folder
+	puts    [][]folder.Folder
+	dels    [][]folder.FolderKey
+}
+
+func (r *recordingRepository) LoadFolders() ([]folder.Folder, error) {
+	return r.folders, nil
+}
+
+func (r *recordingRepository) Commit(put []folder.Folder, del []folder.FolderKey) error {
+	r.puts = append(r.puts, put)
+	r.dels = append(r.dels, del)
+	return nil
+}
+
+func (r *recordingRepository) Close() error {
+	return nil
+}
+
+func Test_folder_NewRepositoryDriver(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	t.Run("only changed rows are committed", func(t *testing.T) {
+		repo := &recordingRepository{folders: []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			{"delta", firstOrgId, "delta"},
+		}}
+		f, err := folder.NewRepositoryDriver(repo)
+		testFolderError(t, err, nil)
+
+		_, err = f.MoveFolder("bravo", "delta")
+		testFolderError(t, err, nil)
+		_, err = f.RenameFolder(firstOrgId, "charlie", "echo")
+		testFolderError(t, err, nil)
+		_, err = f.MoveFolder("bravo", "invalid")
+		testFolderError(t, err, folder.ErrDestinationNotFound)
+
+		if len(repo.puts) != 2 {
+			t.Fatalf("repository got %d commits, want 2", len(repo.puts))
+		}
+		testFolderResults(t, repo.puts[0], []folder.Folder{
+			{"bravo", firstOrgId, "delta.bravo"},
+			{"charlie", firstOrgId, "delta.bravo.charlie"},
+		})
+		testFolderResults(t, repo.puts[1], []folder.Folder{
+			{"echo", firstOrgId, "delta.bravo.echo"},
+		})
+		// rows are keyed by path, so moved folders leave their old rows behind
+		slices.SortFunc(repo.dels[0], func(a, b folder.FolderKey) int {
+			return strings.Compare(a.Paths, b.Paths)
+		})
+		if !slices.Equal(repo.dels[0], []folder.FolderKey{
+			{OrgID: firstOrgId, Paths: "alpha.bravo"},
+			{OrgID: firstOrgId, Paths: "alpha.bravo.charlie"},
+		}) {
+			t.Fatalf("move deleted rows %v, want the old bravo and charlie paths", repo.dels[0])
+		}
+		if !slices.Equal(repo.dels[1], []folder.FolderKey{{OrgID: firstOrgId, Paths: "delta.bravo.charlie"}}) {
+			t.Fatalf("rename deleted rows %v, want charlie", repo.dels[1])
+		}
+	})
+
+	t.Run("invalid folders are refused", func(t *testing.T) {
+		repo := &recordingRepository{folders: []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"alpha", firstOrgId, "alpha"},
+		}}
+		_, err := folder.NewRepositoryDriver(repo)
+		testFolderError(t, err, folder.ErrInvalidFolders)
+	})
+
+	t.Run("bolt repository survives reopening", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.db")
+		repo, err := folder.OpenBoltRepository(path)
+		testFolderError(t, err, nil)
+		f, err := folder.NewRepositoryDriver(repo)
+		testFolderError(t, err, nil)
+
+		steps := []func() ([]folder.Folder, error){
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "alpha", "") },
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "bravo", "alpha") },
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "charlie", "bravo") },
+			func() ([]folder.Folder, error) { return f.CreateFolder(secondOrgId, "alpha", "") },
+			func() ([]folder.Folder, error) { return f.TransferFolder("charlie", secondOrgId, "alpha") },
+			func() ([]folder.Folder, error) { return f.DeleteFolder(firstOrgId, "bravo", false) },
+		}
+		for _, step := range steps {
+			_, err := step()
+			testFolderError(t, err, nil)
+		}
+		testFolderError(t, repo.Close(), nil)
+
+		repo, err = folder.OpenBoltRepository(path)
+		testFolderError(t, err, nil)
+		defer repo.Close()
+
+		want := []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"alpha", secondOrgId, "alpha"},
+			{"charlie", secondOrgId, "alpha.charlie"},
+		}
+		got, err := repo.LoadFolders()
+		testFolderError(t, err, nil)
+		// rows for different orgs share paths, so order on org as well
+		slices.SortFunc(got, compareOrgAndPath)
+		slices.SortFunc(want, compareOrgAndPath)
+		testFolderResults(t, got, want)
+
+		f, err = folder.NewRepositoryDriver(repo)
+		testFolderError(t, err, nil)
+		testFolderResults(t, f.GetAllChildFolders(secondOrgId, "alpha"), []folder.Folder{
+			{"charlie", secondOrgId, "alpha.charlie"},
+		})
+	})
+}
+
+func Test_folder_BoltRepository_SharedNames(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "folders.db")
+	repo, err := folder.OpenBoltRepository(path)
+	testFolderError(t, err, nil)
+
+	// x is used under both a and b
+	folders := []folder.Folder{
+		{"a", firstOrgId, "a"},
+		{"x", firstOrgId, "a.x"},
+		{"b", firstOrgId, "b"},
+		{"x", firstOrgId, "b.x"},
+		{"c", firstOrgId, "c"},
+	}
+	testFolderError(t, repo.Commit(folders, nil), nil)
+	got, err := repo.LoadFolders()
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, folders)
+
+	f, err := folder.NewRepositoryDriver(repo)
+	testFolderError(t, err, nil)
+	_, err = f.MoveFolderByPath(firstOrgId, "a.x", "c")
+	testFolderError(t, err, nil)
+	_, err = f.MoveFolderByPath(firstOrgId, "c.x", "b")
+	testFolderError(t, err, folder.ErrFolderExists)
+	testFolderError(t, repo.Close(), nil)
+
+	repo, err = folder.OpenBoltRepository(path)
+	testFolderError(t, err, nil)
+	defer repo.Close()
+	got, err = repo.LoadFolders()
+	testFolderError(t, err, nil)
+	testFolderResults(t, got, []folder.Folder{
+		{"a", firstOrgId, "a"},
+		{"b", firstOrgId, "b"},
+		{"x", firstOrgId, "b.x"},
+		{"c", firstOrgId, "c"},
+		{"x", firstOrgId, "c.x"},
+	})
+}
+
+func compareOrgAndPath(a, b folder.Folder) int {
+	if c := strings.Compare(a.OrgId.String(), b.OrgId.String()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Paths, b.Paths)
+}
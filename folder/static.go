@@ -3,9 +3,6 @@ package folder
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/fs"
-	"os"
 	"path/filepath"
 	"runtime"
 
@@ -35,11 +32,31 @@ type Folder struct {
 	Paths string    `json:"paths"`
 }
 
+// GenerateOptions controls the shape of the data made by GenerateDataWithOptions.
+// Depth counts the root as the first level, every folder above the last level
+// gets between 1 and MaxChildren children.
+type GenerateOptions struct {
+	Roots       int
+	Depth       int
+	MaxChildren int
+}
+
+// the shape GenerateData uses
+var DefaultGenerateOptions = GenerateOptions{
+	Roots:       MaxRootSet,
+	Depth:       MaxDepth,
+	MaxChildren: MaxChild,
+}
+
 func GenerateData() []Folder {
+	return GenerateDataWithOptions(DefaultGenerateOptions)
+}
+
+func GenerateDataWithOptions(opts GenerateOptions) []Folder {
 	rng, _ := codename.DefaultRNG()
 	tree := []Folder{}
 
-	for i := 0; i < MaxRootSet; i++ {
+	for i := 0; i < opts.Roots; i++ {
 		orgId := uuid.FromStringOrNil(DefaultOrgID)
 		if i%3 == 0 {
 			orgId = uuid.Must(uuid.NewV4())
@@ -49,7 +66,7 @@ func GenerateData() []Folder {
 
 		subtree := make(chan []Folder)
 		go func() {
-			subtree <- generateTree(1, []Folder{
+			subtree <- generateTree(opts, 1, []Folder{
 				{
 					Name:  name,
 					OrgId: orgId,
@@ -63,21 +80,21 @@ func GenerateData() []Folder {
 	return tree
 }
 
-func generateTree(depth int, tree []Folder) []Folder {
+func generateTree(opts GenerateOptions, depth int, tree []Folder) []Folder {
 	rng, _ := codename.DefaultRNG()
 
-	if depth >= MaxDepth {
+	if depth >= opts.Depth || opts.MaxChildren < 1 {
 		return tree
 	}
 
 	for _, t := range tree {
-		numOfChild := rng.Int()%MaxChild + 1
+		numOfChild := rng.Int()%opts.MaxChildren + 1
 		for i := 0; i < numOfChild; i++ {
 			name := codename.Generate(rng, 0)
 
 			childTree := make(chan []Folder)
 			go func() {
-				childTree <- generateTree(depth+1, []Folder{
+				childTree <- generateTree(opts, depth+1, []Folder{
 					{
 						Name:  name,
 						OrgId: t.OrgId,
@@ -104,26 +121,7 @@ func PrettyPrint(b interface{}) {
 }
 
 func GetSampleData() []Folder {
-	_, filename, _, _ := runtime.Caller(0)
-	// fmt.Println(filename)
-	basePath := filepath.Dir(filename)
-	filePath := filepath.Join(basePath, "sample.json")
-
-	// fmt.Println(filePath)
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
-
-	jsonByte, err := io.ReadAll(file)
-	if err != nil {
-		panic(err)
-	}
-
-	folders := []Folder{}
-	err = json.Unmarshal(jsonByte, &folders)
+	folders, err := NewJSONFileStore(sampleDataPath()).Load()
 	if err != nil {
 		panic(err)
 	}
@@ -131,17 +129,19 @@ func GetSampleData() []Folder {
 	return folders
 }
 
-func WriteSampleData(data interface{}) {
-	b := MarshalJson(data)
-	_, filename, _, _ := runtime.Caller(0)
-	fmt.Println(filename)
-	basePath := filepath.Dir(filename)
-	filePath := filepath.Join(basePath, "sample.json")
-
+func WriteSampleData(data []Folder) {
+	filePath := sampleDataPath()
 	fmt.Println(filePath)
 
-	err := os.WriteFile(filePath, b, fs.ModeType)
+	err := NewJSONFileStore(filePath).Save(data)
 	if err != nil {
 		panic(err)
 	}
 }
+
+// sample.json lives next to this source file
+func sampleDataPath() string {
+	_, filename, _, _ := runtime.Caller(0)
+	basePath := filepath.Dir(filename)
+	return filepath.Join(basePath, "sample.json")
+}
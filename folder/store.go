@@ -0,0 +1,117 @@
+package folder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Store loads and saves a complete set of folders.
+type Store interface {
+	Load() ([]Folder, error)
+	Save(folders []Folder) error
+}
+
+// JSONFileStore keeps folders as an indented JSON array in a single file.
+type JSONFileStore struct {
+	Path string
+}
+
+func NewJSONFileStore(path string) *JSONFileStore {
+	return &JSONFileStore{Path: path}
+}
+
+// reads every folder in the file
+// a missing file is reported with an error wrapping fs.ErrNotExist
+func (s *JSONFileStore) Load() ([]Folder, error) {
+	jsonByte, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := []Folder{}
+	if err := json.Unmarshal(jsonByte, &folders); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", s.Path, err)
+	}
+	return folders, nil
+}
+
+// replaces the file with folders, readers see either the old or the new file
+// in full
+func (s *JSONFileStore) Save(folders []Folder) error {
+	jsonByte, err := json.MarshalIndent(folders, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	file, err := replaceFile(s.Path, func(w io.Writer) error {
+		_, err := w.Write(jsonByte)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// hands write a temporary file in the same directory as path, then syncs it
+// and renames it over path, so readers see either the old or the new file in
+// full
+// returns the new file still open with its offset at the end, for callers
+// that keep writing to it
+func replaceFile(path string, write func(w io.Writer) error) (*os.File, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	// no-op once the rename has happened
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	if err := write(writer); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// builds a driver from the folders in store and saves the driver's folders
+// back to store after every successful mutation
+// a store with nothing saved yet starts out empty, one holding folders that
+// fail ValidateFolders is refused with a *ValidationError
+func NewStoredDriver(store Store) (IDriver, error) {
+	folders, err := store.Load()
+	if errors.Is(err, fs.ErrNotExist) {
+		folders, err = []Folder{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := NewDriverWithValidation(folders)
+	if err != nil {
+		return nil, err
+	}
+	return newSyncedDriver(driver, store.Save), nil
+}
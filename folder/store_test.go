@@ -0,0 +1,184 @@
+package folder_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_JSONFileStore(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	t.Run("save then load", func(t *testing.T) {
+		dir := t.TempDir()
+		store := folder.NewJSONFileStore(filepath.Join(dir, "folders.json"))
+		want := []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", firstOrgId, "alpha.bravo"},
+			{"alpha", secondOrgId, "alpha"},
+		}
+
+		if err := store.Save(want); err != nil {
+			t.Fatalf("Save returned error: %s", err)
+		}
+		got, err := store.Load()
+		testFolderError(t, err, nil)
+		testFolderResults(t, got, want)
+
+		// only the data file is left behind
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("Save left %d files behind, want 1", len(entries))
+		}
+		info, err := os.Stat(store.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0o644 {
+			t.Fatalf("Save wrote file mode %v, want %v", info.Mode().Perm(), fs.FileMode(0o644))
+		}
+	})
+
+	t.Run("save replaces existing file", func(t *testing.T) {
+		store := folder.NewJSONFileStore(filepath.Join(t.TempDir(), "folders.json"))
+		if err := store.Save([]folder.Folder{{"alpha", firstOrgId, "alpha"}}); err != nil {
+			t.Fatalf("Save returned error: %s", err)
+		}
+		if err := store.Save([]folder.Folder{{"bravo", firstOrgId, "bravo"}}); err != nil {
+			t.Fatalf("Save returned error: %s", err)
+		}
+
+		got, err := store.Load()
+		testFolderError(t, err, nil)
+		testFolderResults(t, got, []folder.Folder{{"bravo", firstOrgId, "bravo"}})
+	})
+
+	t.Run("load missing file", func(t *testing.T) {
+		store := folder.NewJSONFileStore(filepath.Join(t.TempDir(), "missing.json"))
+		_, err := store.Load()
+		testFolderError(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("load malformed file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "folders.json")
+		if err := os.WriteFile(path, []byte("[{"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := folder.NewJSONFileStore(path).Load(); err == nil {
+			t.Fatal("Load of malformed file returned no error")
+		}
+	})
+
+	t.Run("save to missing directory", func(t *testing.T) {
+		store := folder.NewJSONFileStore(filepath.Join(t.TempDir(), "missing", "folders.json"))
+		if err := store.Save([]folder.Folder{}); err == nil {
+			t.Fatal("Save to missing directory returned no error")
+		}
+	})
+}
+
+// Store that records saves and can be made to fail
+type recordingStore struct {
+	folders []folder.Folder
+	saves   int
+	err     error
+}
+
+func (s *recordingStore) Load() ([]folder.Folder, error) {
+	return s.folders, nil
+}
+
+func (s *recordingStore) Save(folders []folder.Folder) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.folders = folders
+	s.saves++
+	return nil
+}
+
+func Test_folder_NewStoredDriver(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	t.Run("mutations are saved", func(t *testing.T) {
+		store := folder.NewJSONFileStore(filepath.Join(t.TempDir(), "folders.json"))
+		f, err := folder.NewStoredDriver(store)
+		testFolderError(t, err, nil)
+
+		steps := []func() ([]folder.Folder, error){
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "alpha", "") },
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "bravo", "") },
+			func() ([]folder.Folder, error) { return f.CreateFolder(firstOrgId, "charlie", "bravo") },
+			func() ([]folder.Folder, error) { return f.MoveFolder("bravo", "alpha") },
+			func() ([]folder.Folder, error) { return f.RenameFolder(firstOrgId, "charlie", "delta") },
+			func() ([]folder.Folder, error) { return f.CopyFolder(firstOrgId, "delta", "alpha", nil) },
+			func() ([]folder.Folder, error) { return f.DeleteFolder(firstOrgId, "delta-copy", false) },
+			func() ([]folder.Folder, error) { return f.TransferFolder("bravo", secondOrgId, "") },
+			func() ([]folder.Folder, error) { return f.MoveFolderToRoot(secondOrgId, "delta") },
+			func() ([]folder.Folder, error) { return f.MoveFolderByPath(secondOrgId, "bravo", "delta") },
+		}
+		for _, step := range steps {
+			_, err := step()
+			testFolderError(t, err, nil)
+		}
+
+		got, err := store.Load()
+		testFolderError(t, err, nil)
+		testFolderResults(t, got, []folder.Folder{
+			{"alpha", firstOrgId, "alpha"},
+			{"bravo", secondOrgId, "delta.bravo"},
+			{"delta", secondOrgId, "delta"},
+		})
+
+		// a fresh driver picks up where the last one stopped
+		reloaded, err := folder.NewStoredDriver(store)
+		testFolderError(t, err, nil)
+		testFolderResults(t, reloaded.GetFoldersByOrgID(secondOrgId), []folder.Folder{
+			{"bravo", secondOrgId, "delta.bravo"},
+			{"delta", secondOrgId, "delta"},
+		})
+	})
+
+	t.Run("rejected mutations are not saved", func(t *testing.T) {
+		store := &recordingStore{folders: []folder.Folder{{"alpha", firstOrgId, "alpha"}}}
+		f, err := folder.NewStoredDriver(store)
+		testFolderError(t, err, nil)
+
+		_, err = f.MoveFolder("alpha", "invalid")
+		testFolderError(t, err, folder.ErrDestinationNotFound)
+		if store.saves != 0 {
+			t.Fatalf("rejected mutation was saved %d times", store.saves)
+		}
+	})
+
+	t.Run("save failures are returned", func(t *testing.T) {
+		saveErr := errors.New("disk full")
+		store := &recordingStore{folders: []folder.Folder{{"alpha", firstOrgId, "alpha"}}, err: saveErr}
+		f, err := folder.NewStoredDriver(store)
+		testFolderError(t, err, nil)
+
+		_, err = f.CreateFolder(firstOrgId, "bravo", "alpha")
+		testFolderError(t, err, saveErr)
+	})
+
+	t.Run("invalid folders are refused", func(t *testing.T) {
+		store := &recordingStore{folders: []folder.Folder{{"bravo", firstOrgId, "alpha.bravo"}}}
+		f, err := folder.NewStoredDriver(store)
+		testFolderError(t, err, folder.ErrInvalidFolders)
+		if f != nil {
+			t.Fatalf("got a driver for invalid folders")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package folder
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+// wraps an IDriver and hands the full folder set to persist after every
+// successful mutation, reads go straight to the wrapped driver
+// mu serialises mutations with their persist call so the last one saved always
+// matches the latest state
+type syncedDriver struct {
+	IDriver
+	mu      sync.Mutex
+	persist func([]Folder) error
+}
+
+func newSyncedDriver(driver IDriver, persist func([]Folder) error) *syncedDriver {
+	return &syncedDriver{
+		IDriver: driver,
+		persist: persist,
+	}
+}
+
+// runs mutate and persists its result
+// a failed persist leaves the mutation applied in memory only
+func (d *syncedDriver) syncAfter(mutate func() ([]Folder, error)) ([]Folder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	folders, err := mutate()
+	if err != nil {
+		return folders, err
+	}
+	if err := d.persist(folders); err != nil {
+		return []Folder{}, fmt.Errorf("folders changed but not saved: %w", err)
+	}
+	return folders, nil
+}
+
+func (d *syncedDriver) MoveFolder(name string, dst string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.MoveFolder(name, dst)
+	})
+}
+
+func (d *syncedDriver) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.MoveFolderInOrg(orgID, name, dst)
+	})
+}
+
+func (d *syncedDriver) MoveFolderByPath(orgID uuid.UUID, srcPath string, dstPath string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.MoveFolderByPath(orgID, srcPath, dstPath)
+	})
+}
+
+func (d *syncedDriver) CreateFolder(orgID uuid.UUID, name string, parentName string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.CreateFolder(orgID, name, parentName)
+	})
+}
+
+func (d *syncedDriver) DeleteFolder(orgID uuid.UUID, name string, recursive bool) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.DeleteFolder(orgID, name, recursive)
+	})
+}
+
+func (d *syncedDriver) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.RenameFolder(orgID, oldName, newName)
+	})
+}
+
+func (d *syncedDriver) MoveFolderToRoot(orgID uuid.UUID, name string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.MoveFolderToRoot(orgID, name)
+	})
+}
+
+func (d *syncedDriver) TransferFolder(name string, dstOrgID uuid.UUID, dstParent string) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.TransferFolder(name, dstOrgID, dstParent)
+	})
+}
+
+func (d *syncedDriver) CopyFolder(orgID uuid.UUID, name string, dstParent string, nameFn NameFunc) ([]Folder, error) {
+	return d.syncAfter(func() ([]Folder, error) {
+		return d.IDriver.CopyFolder(orgID, name, dstParent, nameFn)
+	})
+}
@@ -0,0 +1,75 @@
+package folder
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// moves Folder name and its subtree under Folder dstParent in dstOrgID
+// an empty dstParent makes name a top level folder of dstOrgID
+// nothing is changed unless every folder in the subtree can be transferred
+// runs in O(k) in the size of the subtree rooted at name
+func (f *driver) TransferFolder(name string, dstOrgID uuid.UUID, dstParent string) ([]Folder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sources := f.lookupAllOrgs(name)
+	if len(sources) == 0 {
+		return []Folder{}, &MoveError{name, dstParent, uuid.Nil, ErrSourceNotFound}
+	}
+	if len(sources) > 1 {
+		return []Folder{}, &MoveError{name, dstParent, uuid.Nil, ErrSourceAmbiguous}
+	}
+	node := sources[0]
+	srcOrgID := node.folder.OrgId
+
+	var parent *FolderTreeNode
+	if dstParent != "" {
+		var err error
+		if parent, err = f.findDestination(dstOrgID, dstParent); err != nil {
+			return []Folder{}, &MoveError{name, dstParent, srcOrgID, err}
+		}
+	}
+
+	// plain moves inside one org
+	if srcOrgID == dstOrgID {
+		if parent == nil {
+			if err := f.moveToRoot(node); err != nil {
+				return []Folder{}, &MoveError{name, dstParent, srcOrgID, err}
+			}
+			return f.allFolders(), nil
+		}
+		if name == dstParent {
+			return []Folder{}, &MoveError{name, dstParent, srcOrgID, ErrMoveToSelf}
+		}
+		if err := f.moveFolder(node, parent); err != nil {
+			return []Folder{}, &MoveError{name, dstParent, srcOrgID, err}
+		}
+		return f.allFolders(), nil
+	}
+
+	// check the whole subtree before changing anything
+	subtree := node.collectNodes()
+	for _, curr := range subtree {
+		if f.nameInUse(dstOrgID, curr.folder.Name) {
+			return []Folder{}, &MoveError{name, dstParent, srcOrgID, ErrFolderExists}
+		}
+	}
+
+	f.detach(node)
+	f.addOrg(dstOrgID)
+	for _, curr := range subtree {
+		f.unindex(curr)
+		curr.folder.OrgId = dstOrgID
+		f.index(curr)
+	}
+	f.attach(node, parent)
+
+	// update paths
+	newPrefix := ""
+	if parent != nil {
+		newPrefix = parent.folder.Paths
+	}
+	fixPaths(node, newPrefix)
+
+	return f.allFolders(), nil
+}
@@ -0,0 +1,191 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_TransferFolder(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name      string
+		target    string
+		dstOrgID  uuid.UUID
+		dstParent string
+		folders   []folder.Folder
+		want      []folder.Folder
+		err       error
+	}{
+		{
+			"transfer subtree under folder in another organization",
+			"bravo",
+			secondOrgId,
+			"delta",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", secondOrgId, "delta"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "delta.bravo"},
+				{"charlie", secondOrgId, "delta.bravo.charlie"},
+				{"delta", secondOrgId, "delta"},
+			},
+			nil,
+		},
+		{
+			"transfer subtree to top level of another organization",
+			"bravo",
+			secondOrgId,
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", secondOrgId, "bravo"},
+				{"charlie", secondOrgId, "bravo.charlie"},
+			},
+			nil,
+		},
+		{
+			"transfer top-level folder",
+			"alpha",
+			secondOrgId,
+			"delta",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", secondOrgId, "delta"},
+			},
+			[]folder.Folder{
+				{"alpha", secondOrgId, "delta.alpha"},
+				{"bravo", secondOrgId, "delta.alpha.bravo"},
+				{"delta", secondOrgId, "delta"},
+			},
+			nil,
+		},
+		{
+			"transfer within the same organization",
+			"charlie",
+			firstOrgId,
+			"delta",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "alpha.charlie"},
+				{"delta", firstOrgId, "delta"},
+			},
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "delta.charlie"},
+				{"delta", firstOrgId, "delta"},
+			},
+			nil,
+		},
+		{
+			"attempt transfer with name collision in subtree",
+			"bravo",
+			secondOrgId,
+			"delta",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"delta", secondOrgId, "delta"},
+				{"charlie", secondOrgId, "delta.charlie"},
+			},
+			[]folder.Folder{},
+			folder.ErrFolderExists,
+		},
+		{
+			"attempt transfer to non-existant parent",
+			"bravo",
+			secondOrgId,
+			"invalid",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrDestinationNotFound,
+		},
+		{
+			"attempt transfer non-existant folder",
+			"invalid",
+			secondOrgId,
+			"",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]folder.Folder{},
+			folder.ErrSourceNotFound,
+		},
+		{
+			"attempt transfer into own subtree",
+			"alpha",
+			firstOrgId,
+			"bravo",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]folder.Folder{},
+			folder.ErrMoveIntoDescendant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(tt.folders)
+			got, err := f.TransferFolder(tt.target, tt.dstOrgID, tt.dstParent)
+
+			testFolderResults(t, got, tt.want)
+			testFolderError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_folder_TransferFolder_AllOrNothing(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	f := folder.NewDriver([]folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"charlie", secondOrgId, "charlie"},
+	})
+
+	_, err := f.TransferFolder("alpha", secondOrgId, "")
+	testFolderError(t, err, folder.ErrFolderExists)
+
+	// a rejected transfer leaves both orgs untouched
+	testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+	})
+	testFolderResults(t, f.GetFoldersByOrgID(secondOrgId), []folder.Folder{
+		{"charlie", secondOrgId, "charlie"},
+	})
+
+	// once the conflict is gone the transferred folders resolve in the new org
+	_, err = f.RenameFolder(secondOrgId, "charlie", "delta")
+	testFolderError(t, err, nil)
+	_, err = f.TransferFolder("alpha", secondOrgId, "delta")
+	testFolderError(t, err, nil)
+	testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), nil)
+	testFolderResults(t, f.GetAllChildFolders(secondOrgId, "alpha"), []folder.Folder{
+		{"bravo", secondOrgId, "delta.alpha.bravo"},
+		{"charlie", secondOrgId, "delta.alpha.bravo.charlie"},
+	})
+}
@@ -0,0 +1,132 @@
+package folder
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// appended to the name of every marked folder in a rendered tree
+const TreeMark = " *"
+
+// TreeOptions controls how RenderTree and RenderFolders draw a tree.
+// MaxDepth counts the levels drawn, the top folder included, and 0 draws every
+// level. Folders named in Marked are drawn with TreeMark after their name.
+type TreeOptions struct {
+	MaxDepth int
+	Marked   []string
+}
+
+// writes the folder called name and its subtree to w, or every top level
+// folder of orgID and their subtrees when name is empty
+func (f *driver) RenderTree(w io.Writer, orgID uuid.UUID, name string, opts TreeOptions) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if name == "" {
+		return writeTree(w, sortedNodes(f.folderTree[orgID]), opts)
+	}
+
+	node, err := f.find(orgID, name)
+	if err != nil {
+		return err
+	}
+	return writeTree(w, []*FolderTreeNode{node}, opts)
+}
+
+// writes folders to w as a tree without building a driver
+// a folder whose parent isn't in folders is drawn as a top level folder, so any
+// subset of an organization can be drawn. Each organization is headed by its
+// ID when folders span more than one.
+func RenderFolders(w io.Writer, folders []Folder, opts TreeOptions) error {
+	folders = slices.Clone(folders)
+	byOrg := make(map[uuid.UUID]map[string]*FolderTreeNode)
+	for i := range folders {
+		folder := &folders[i]
+		if byOrg[folder.OrgId] == nil {
+			byOrg[folder.OrgId] = make(map[string]*FolderTreeNode)
+		}
+		byOrg[folder.OrgId][folder.Paths] = NewFolderTreeNode(folder)
+	}
+
+	orgIDs := slices.SortedFunc(maps.Keys(byOrg), compareOrgIDs)
+	for i, orgID := range orgIDs {
+		var roots []*FolderTreeNode
+		for path, node := range byOrg[orgID] {
+			parent, found := byOrg[orgID][parentPath(path)]
+			if !found {
+				roots = append(roots, node)
+				continue
+			}
+			parent.children[node.folder.Name] = node
+			node.parent = parent
+		}
+
+		if len(orgIDs) > 1 {
+			header := "org " + orgID.String() + "\n"
+			if i > 0 {
+				header = "\n" + header
+			}
+			if _, err := io.WriteString(w, header); err != nil {
+				return err
+			}
+		}
+		if err := writeTree(w, roots, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns path without its last segment, or "" for a top level path
+func parentPath(path string) string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+// draws each root and its subtree with box-drawing connectors, siblings are
+// drawn in name order
+func writeTree(w io.Writer, roots []*FolderTreeNode, opts TreeOptions) error {
+	var b strings.Builder
+	for _, root := range sortedByName(roots) {
+		b.WriteString(treeLabel(root, opts))
+		b.WriteByte('\n')
+		writeSubtree(&b, root, "", 1, opts)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeSubtree(b *strings.Builder, node *FolderTreeNode, prefix string, depth int, opts TreeOptions) {
+	if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+		return
+	}
+
+	children := sortedNodes(node.children)
+	for i, child := range children {
+		connector, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, indent = "└── ", "    "
+		}
+
+		b.WriteString(prefix)
+		b.WriteString(connector)
+		b.WriteString(treeLabel(child, opts))
+		b.WriteByte('\n')
+		writeSubtree(b, child, prefix+indent, depth+1, opts)
+	}
+}
+
+func treeLabel(node *FolderTreeNode, opts TreeOptions) string {
+	if slices.Contains(opts.Marked, node.folder.Name) {
+		return node.folder.Name + TreeMark
+	}
+	return node.folder.Name
+}
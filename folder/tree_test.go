@@ -0,0 +1,191 @@
+package folder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_RenderTree(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	folders := []folder.Folder{
+		{"alpha", firstOrgId, "alpha"},
+		{"delta", firstOrgId, "alpha.delta"},
+		{"bravo", firstOrgId, "alpha.bravo"},
+		{"charlie", firstOrgId, "alpha.bravo.charlie"},
+		{"echo", firstOrgId, "echo"},
+		{"foxtrot", secondOrgId, "foxtrot"},
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name  string
+		orgID uuid.UUID
+		root  string
+		opts  folder.TreeOptions
+		want  string
+		err   error
+	}{
+		{
+			"whole organization",
+			firstOrgId,
+			"",
+			folder.TreeOptions{},
+			"alpha\n" +
+				"├── bravo\n" +
+				"│   └── charlie\n" +
+				"└── delta\n" +
+				"echo\n",
+			nil,
+		},
+		{
+			"subtree",
+			firstOrgId,
+			"bravo",
+			folder.TreeOptions{},
+			"bravo\n" +
+				"└── charlie\n",
+			nil,
+		},
+		{
+			"max depth",
+			firstOrgId,
+			"alpha",
+			folder.TreeOptions{MaxDepth: 2},
+			"alpha\n" +
+				"├── bravo\n" +
+				"└── delta\n",
+			nil,
+		},
+		{
+			"top level only",
+			firstOrgId,
+			"",
+			folder.TreeOptions{MaxDepth: 1},
+			"alpha\n" +
+				"echo\n",
+			nil,
+		},
+		{
+			"marked folders",
+			firstOrgId,
+			"alpha",
+			folder.TreeOptions{Marked: []string{"alpha", "charlie"}},
+			"alpha *\n" +
+				"├── bravo\n" +
+				"│   └── charlie *\n" +
+				"└── delta\n",
+			nil,
+		},
+		{
+			"unknown organization",
+			uuid.Nil,
+			"",
+			folder.TreeOptions{},
+			"",
+			nil,
+		},
+		{
+			"missing folder",
+			firstOrgId,
+			"zulu",
+			folder.TreeOptions{},
+			"",
+			folder.ErrFolderNotFound,
+		},
+		{
+			"folder in another organization",
+			firstOrgId,
+			"foxtrot",
+			folder.TreeOptions{},
+			"",
+			folder.ErrFolderNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := folder.NewDriver(append([]folder.Folder(nil), folders...))
+
+			var b strings.Builder
+			err := f.RenderTree(&b, tt.orgID, tt.root, tt.opts)
+
+			testFolderError(t, err, tt.err)
+			if b.String() != tt.want {
+				t.Fatalf("RenderTree got:\n%s\nwant:\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_folder_RenderFolders(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		folders []folder.Folder
+		opts    folder.TreeOptions
+		want    string
+	}{
+		{
+			"no folders",
+			[]folder.Folder{},
+			folder.TreeOptions{},
+			"",
+		},
+		{
+			"subset without its root",
+			[]folder.Folder{
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", firstOrgId, "alpha.delta"},
+			},
+			folder.TreeOptions{},
+			"bravo\n" +
+				"└── charlie\n" +
+				"delta\n",
+		},
+		{
+			"similar paths",
+			[]folder.Folder{
+				{"alpha-x", firstOrgId, "alpha-x"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", firstOrgId, "alpha"},
+			},
+			folder.TreeOptions{},
+			"alpha\n" +
+				"└── bravo\n" +
+				"alpha-x\n",
+		},
+		{
+			"several organizations",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			folder.TreeOptions{MaxDepth: 1, Marked: []string{"alpha"}},
+			"org " + SecondOrgID + "\n" +
+				"alpha *\n" +
+				"\n" +
+				"org " + FirstOrgID + "\n" +
+				"alpha *\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := folder.RenderFolders(&b, tt.folders, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Fatalf("RenderFolders got:\n%s\nwant:\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
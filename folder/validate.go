@@ -0,0 +1,147 @@
+package folder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// ErrInvalidFolders is wrapped by the ValidationError NewDriverWithValidation
+// returns, compare with errors.Is.
+var ErrInvalidFolders = errors.New("Folders are not a well-formed tree")
+
+// ProblemKind names a way a Folder can break the tree NewDriver builds.
+type ProblemKind string
+
+const (
+	// Name is empty or contains the "." path separator.
+	ProblemInvalidName ProblemKind = "invalid_name"
+	// Paths is empty or has an empty segment.
+	ProblemInvalidPath ProblemKind = "invalid_path"
+	// The last segment of Paths isn't Name.
+	ProblemNameMismatch ProblemKind = "name_mismatch"
+	// Another folder in the organization already has this Paths. Names can
+	// repeat under different parents.
+	ProblemDuplicatePath ProblemKind = "duplicate_path"
+	// No folder in the organization has the parent path.
+	ProblemMissingParent ProblemKind = "missing_parent"
+	// The parent path only exists in a different organization.
+	ProblemParentInOtherOrg ProblemKind = "parent_in_other_org"
+)
+
+// Problem is one reason a folder can't be loaded. Index is the folder's
+// position in the validated slice, Related is the position of the folder it
+// conflicts with or -1 when there isn't one.
+type Problem struct {
+	Index   int
+	Kind    ProblemKind
+	Folder  Folder
+	Related int
+}
+
+func (p Problem) String() string {
+	s := fmt.Sprintf("folder %d (%q in org %s, path %q): %s", p.Index, p.Folder.Name, p.Folder.OrgId, p.Folder.Paths, p.Kind)
+	if p.Related >= 0 {
+		s += fmt.Sprintf(", see folder %d", p.Related)
+	}
+	return s
+}
+
+// ValidationError lists every Problem found in a set of folders.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems)+1)
+	lines = append(lines, fmt.Sprintf("%s: %d problems", ErrInvalidFolders, len(e.Problems)))
+	for _, p := range e.Problems {
+		lines = append(lines, "\t"+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidFolders
+}
+
+// builds a driver like NewDriver after checking folders with ValidateFolders
+// returns a *ValidationError listing every problem instead of building a
+// broken tree
+func NewDriverWithValidation(folders []Folder) (IDriver, error) {
+	if problems := ValidateFolders(folders); len(problems) > 0 {
+		return nil, &ValidationError{problems}
+	}
+	return NewDriver(folders), nil
+}
+
+// returns every problem that stops folders from forming a tree, ordered by
+// index, or nil when folders are well-formed
+// a folder can have more than one problem, problems aren't passed down so the
+// children of a broken folder are only reported for their own
+// runs in O(n) in the total length of the paths
+func ValidateFolders(folders []Folder) []Problem {
+	type pathKey struct {
+		orgID uuid.UUID
+		path  string
+	}
+	byPath := make(map[pathKey]int, len(folders))
+	orgsByPath := make(map[string]int, len(folders))
+	for i, folder := range folders {
+		if _, found := byPath[pathKey{folder.OrgId, folder.Paths}]; !found {
+			byPath[pathKey{folder.OrgId, folder.Paths}] = i
+		}
+		if _, found := orgsByPath[folder.Paths]; !found {
+			orgsByPath[folder.Paths] = i
+		}
+	}
+
+	var problems []Problem
+	report := func(i int, kind ProblemKind, related int) {
+		problems = append(problems, Problem{i, kind, folders[i], related})
+	}
+
+	for i, folder := range folders {
+		if !validFolderName(folder.Name) {
+			report(i, ProblemInvalidName, -1)
+		}
+		if first := byPath[pathKey{folder.OrgId, folder.Paths}]; first != i {
+			report(i, ProblemDuplicatePath, first)
+		}
+
+		segments := strings.Split(folder.Paths, ".")
+		if !validPath(segments) {
+			report(i, ProblemInvalidPath, -1)
+			continue
+		}
+		if segments[len(segments)-1] != folder.Name {
+			report(i, ProblemNameMismatch, -1)
+		}
+		if len(segments) == 1 {
+			continue
+		}
+
+		parent := strings.Join(segments[:len(segments)-1], ".")
+		if _, found := byPath[pathKey{folder.OrgId, parent}]; found {
+			continue
+		}
+		if other, found := orgsByPath[parent]; found {
+			report(i, ProblemParentInOtherOrg, other)
+		} else {
+			report(i, ProblemMissingParent, -1)
+		}
+	}
+
+	return problems
+}
+
+func validPath(segments []string) bool {
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
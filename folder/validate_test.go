@@ -0,0 +1,217 @@
+package folder_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+func Test_folder_ValidateFolders(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+	secondOrgId := uuid.FromStringOrNil(SecondOrgID)
+
+	type problem struct {
+		index   int
+		kind    folder.ProblemKind
+		related int
+	}
+
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		folders []folder.Folder
+		want    []problem
+	}{
+		{
+			"no folders",
+			[]folder.Folder{},
+			nil,
+		},
+		{
+			"well-formed",
+			[]folder.Folder{
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			nil,
+		},
+		{
+			"empty name",
+			[]folder.Folder{
+				{"", firstOrgId, "alpha"},
+			},
+			[]problem{
+				{0, folder.ProblemInvalidName, -1},
+				{0, folder.ProblemNameMismatch, -1},
+			},
+		},
+		{
+			"name containing the separator",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha.bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]problem{
+				{1, folder.ProblemInvalidName, -1},
+				{1, folder.ProblemNameMismatch, -1},
+			},
+		},
+		{
+			"empty path segment",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha..bravo"},
+				{"charlie", firstOrgId, ""},
+			},
+			[]problem{
+				{1, folder.ProblemInvalidPath, -1},
+				{2, folder.ProblemInvalidPath, -1},
+			},
+		},
+		{
+			"last segment is not the name",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.charlie"},
+			},
+			[]problem{
+				{1, folder.ProblemNameMismatch, -1},
+			},
+		},
+		{
+			"duplicate names under different parents",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"delta", firstOrgId, "delta"},
+				{"bravo", firstOrgId, "delta.bravo"},
+				{"bravo", firstOrgId, "bravo"},
+			},
+			nil,
+		},
+		{
+			"duplicate paths",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+				{"bravo", secondOrgId, "alpha.bravo"},
+				{"alpha", secondOrgId, "alpha"},
+			},
+			[]problem{
+				{2, folder.ProblemDuplicatePath, 1},
+			},
+		},
+		{
+			"missing parent",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			[]problem{
+				{1, folder.ProblemMissingParent, -1},
+			},
+		},
+		{
+			"parent in another organization",
+			[]folder.Folder{
+				{"alpha", secondOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			[]problem{
+				{1, folder.ProblemParentInOtherOrg, 0},
+			},
+		},
+		{
+			"problems are reported in index order",
+			[]folder.Folder{
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+				{"alpha", firstOrgId, "alpha"},
+				{"alpha", firstOrgId, "alpha"},
+			},
+			[]problem{
+				{0, folder.ProblemMissingParent, -1},
+				{2, folder.ProblemDuplicatePath, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []problem
+			for _, p := range folder.ValidateFolders(tt.folders) {
+				if p.Folder != tt.folders[p.Index] {
+					t.Errorf("problem %v has folder %v, want %v", p, p.Folder, tt.folders[p.Index])
+				}
+				got = append(got, problem{p.Index, p.Kind, p.Related})
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatalf("ValidateFolders problems do not match expected:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_folder_NewDriverWithValidation(t *testing.T) {
+	firstOrgId := uuid.FromStringOrNil(FirstOrgID)
+
+	t.Parallel()
+	tests := [...]struct {
+		name     string
+		folders  []folder.Folder
+		problems int
+	}{
+		{
+			"well-formed",
+			[]folder.Folder{
+				{"alpha", firstOrgId, "alpha"},
+				{"bravo", firstOrgId, "alpha.bravo"},
+			},
+			0,
+		},
+		{
+			// NewDriver panics on this input
+			"missing parent",
+			[]folder.Folder{
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			1,
+		},
+		{
+			"several problems",
+			[]folder.Folder{
+				{"", firstOrgId, "alpha"},
+				{"charlie", firstOrgId, "alpha.bravo.charlie"},
+			},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := folder.NewDriverWithValidation(tt.folders)
+			if tt.problems == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				testFolderResults(t, f.GetFoldersByOrgID(firstOrgId), tt.folders)
+				return
+			}
+
+			testFolderError(t, err, folder.ErrInvalidFolders)
+			var validationErr *folder.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("error %v is not a *ValidationError", err)
+			}
+			if len(validationErr.Problems) != tt.problems {
+				t.Errorf("got %d problems, want %d:\n%v", len(validationErr.Problems), tt.problems, err)
+			}
+			if f != nil {
+				t.Errorf("got a driver alongside the error")
+			}
+		})
+	}
+}
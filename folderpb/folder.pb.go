@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: folder.proto
+
+package folderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Folder mirrors folder.Folder, org_id is the organization UUID as a string.
+type Folder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Paths         string                 `protobuf:"bytes,3,opt,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_folder_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Folder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Folder) ProtoMessage() {}
+
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Folder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Folder) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Folder) GetPaths() string {
+	if x != nil {
+		return x.Paths
+	}
+	return ""
+}
+
+type GetFoldersByOrgIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFoldersByOrgIDRequest) Reset() {
+	*x = GetFoldersByOrgIDRequest{}
+	mi := &file_folder_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFoldersByOrgIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFoldersByOrgIDRequest) ProtoMessage() {}
+
+func (x *GetFoldersByOrgIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFoldersByOrgIDRequest.ProtoReflect.Descriptor instead.
+func (*GetFoldersByOrgIDRequest) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFoldersByOrgIDRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetFoldersByOrgIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFoldersByOrgIDResponse) Reset() {
+	*x = GetFoldersByOrgIDResponse{}
+	mi := &file_folder_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFoldersByOrgIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFoldersByOrgIDResponse) ProtoMessage() {}
+
+func (x *GetFoldersByOrgIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFoldersByOrgIDResponse.ProtoReflect.Descriptor instead.
+func (*GetFoldersByOrgIDResponse) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetFoldersByOrgIDResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type GetAllChildFoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllChildFoldersRequest) Reset() {
+	*x = GetAllChildFoldersRequest{}
+	mi := &file_folder_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllChildFoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllChildFoldersRequest) ProtoMessage() {}
+
+func (x *GetAllChildFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllChildFoldersRequest.ProtoReflect.Descriptor instead.
+func (*GetAllChildFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAllChildFoldersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *GetAllChildFoldersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// one batch of a streamed subtree
+type GetAllChildFoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllChildFoldersResponse) Reset() {
+	*x = GetAllChildFoldersResponse{}
+	mi := &file_folder_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllChildFoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllChildFoldersResponse) ProtoMessage() {}
+
+func (x *GetAllChildFoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllChildFoldersResponse.ProtoReflect.Descriptor instead.
+func (*GetAllChildFoldersResponse) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAllChildFoldersResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+// an empty dst moves the folder to the top level of its organization
+type MoveFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Dst           string                 `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderRequest) Reset() {
+	*x = MoveFolderRequest{}
+	mi := &file_folder_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderRequest) ProtoMessage() {}
+
+func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderRequest.ProtoReflect.Descriptor instead.
+func (*MoveFolderRequest) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MoveFolderRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetDst() string {
+	if x != nil {
+		return x.Dst
+	}
+	return ""
+}
+
+// the organization's folders after the move
+type MoveFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderResponse) Reset() {
+	*x = MoveFolderResponse{}
+	mi := &file_folder_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderResponse) ProtoMessage() {}
+
+func (x *MoveFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_folder_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderResponse.ProtoReflect.Descriptor instead.
+func (*MoveFolderResponse) Descriptor() ([]byte, []int) {
+	return file_folder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MoveFolderResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+var File_folder_proto protoreflect.FileDescriptor
+
+const file_folder_proto_rawDesc = "" +
+	"\n" +
+	"\ffolder.proto\x12\n" +
+	"folders.v1\"I\n" +
+	"\x06Folder\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05paths\x18\x03 \x01(\tR\x05paths\"1\n" +
+	"\x18GetFoldersByOrgIDRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"I\n" +
+	"\x19GetFoldersByOrgIDResponse\x12,\n" +
+	"\afolders\x18\x01 \x03(\v2\x12.folders.v1.FolderR\afolders\"F\n" +
+	"\x19GetAllChildFoldersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"J\n" +
+	"\x1aGetAllChildFoldersResponse\x12,\n" +
+	"\afolders\x18\x01 \x03(\v2\x12.folders.v1.FolderR\afolders\"P\n" +
+	"\x11MoveFolderRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03dst\x18\x03 \x01(\tR\x03dst\"B\n" +
+	"\x12MoveFolderResponse\x12,\n" +
+	"\afolders\x18\x01 \x03(\v2\x12.folders.v1.FolderR\afolders2\xa5\x02\n" +
+	"\rFolderService\x12`\n" +
+	"\x11GetFoldersByOrgID\x12$.folders.v1.GetFoldersByOrgIDRequest\x1a%.folders.v1.GetFoldersByOrgIDResponse\x12e\n" +
+	"\x12GetAllChildFolders\x12%.folders.v1.GetAllChildFoldersRequest\x1a&.folders.v1.GetAllChildFoldersResponse0\x01\x12K\n" +
+	"\n" +
+	"MoveFolder\x12\x1d.folders.v1.MoveFolderRequest\x1a\x1e.folders.v1.MoveFolderResponseB2Z0github.com/georgechieng-sc/interns-2022/folderpbb\x06proto3"
+
+var (
+	file_folder_proto_rawDescOnce sync.Once
+	file_folder_proto_rawDescData []byte
+)
+
+func file_folder_proto_rawDescGZIP() []byte {
+	file_folder_proto_rawDescOnce.Do(func() {
+		file_folder_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_folder_proto_rawDesc), len(file_folder_proto_rawDesc)))
+	})
+	return file_folder_proto_rawDescData
+}
+
+var file_folder_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_folder_proto_goTypes = []any{
+	(*Folder)(nil),                     // 0: folders.v1.Folder
+	(*GetFoldersByOrgIDRequest)(nil),   // 1: folders.v1.GetFoldersByOrgIDRequest
+	(*GetFoldersByOrgIDResponse)(nil),  // 2: folders.v1.GetFoldersByOrgIDResponse
+	(*GetAllChildFoldersRequest)(nil),  // 3: folders.v1.GetAllChildFoldersRequest
+	(*GetAllChildFoldersResponse)(nil), // 4: folders.v1.GetAllChildFoldersResponse
+	(*MoveFolderRequest)(nil),          // 5: folders.v1.MoveFolderRequest
+	(*MoveFolderResponse)(nil),         // 6: folders.v1.MoveFolderResponse
+}
+var file_folder_proto_depIdxs = []int32{
+	0, // 0: folders.v1.GetFoldersByOrgIDResponse.folders:type_name -> folders.v1.Folder
+	0, // 1: folders.v1.GetAllChildFoldersResponse.folders:type_name -> folders.v1.Folder
+	0, // 2: folders.v1.MoveFolderResponse.folders:type_name -> folders.v1.Folder
+	1, // 3: folders.v1.FolderService.GetFoldersByOrgID:input_type -> folders.v1.GetFoldersByOrgIDRequest
+	3, // 4: folders.v1.FolderService.GetAllChildFolders:input_type -> folders.v1.GetAllChildFoldersRequest
+	5, // 5: folders.v1.FolderService.MoveFolder:input_type -> folders.v1.MoveFolderRequest
+	2, // 6: folders.v1.FolderService.GetFoldersByOrgID:output_type -> folders.v1.GetFoldersByOrgIDResponse
+	4, // 7: folders.v1.FolderService.GetAllChildFolders:output_type -> folders.v1.GetAllChildFoldersResponse
+	6, // 8: folders.v1.FolderService.MoveFolder:output_type -> folders.v1.MoveFolderResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_folder_proto_init() }
+func file_folder_proto_init() {
+	if File_folder_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_folder_proto_rawDesc), len(file_folder_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_folder_proto_goTypes,
+		DependencyIndexes: file_folder_proto_depIdxs,
+		MessageInfos:      file_folder_proto_msgTypes,
+	}.Build()
+	File_folder_proto = out.File
+	file_folder_proto_goTypes = nil
+	file_folder_proto_depIdxs = nil
+}
@@ -0,0 +1,5 @@
+// Package folderpb holds the protobuf messages and gRPC service for folder
+// operations, generated from folder.proto.
+package folderpb
+
+//go:generate buf generate
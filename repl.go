@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+)
+
+// repl holds the driver the REPL is working on, load and generate replace it
+type repl struct {
+	out          io.Writer
+	folderDriver folder.IDriver
+}
+
+// orders accepted by the list command
+var listOrders = map[string]folder.FolderOrder{
+	"path": folder.OrderByPath,
+	"name": folder.OrderByName,
+	"dfs":  folder.OrderPreOrder,
+}
+
+func runREPL(in io.Reader, out io.Writer) {
+	fmt.Fprintln(out, "Starting Virtual File System REPL...")
+	fmt.Fprintln(out, "Available commands:")
+	fmt.Fprintln(out, "  - list [path|name|dfs]: List all folders by path, by name or depth first (default path)")
+	fmt.Fprintln(out, "  - get <orgID>: Get folders by organization ID")
+	fmt.Fprintln(out, "  - children <name>: Get children by name")
+	fmt.Fprintln(out, "  - move <src,dst>: Move src to child of dst")
+	fmt.Fprintln(out, "  - tree [orgID] [name]: Draw every folder, an organization or a subtree as a tree")
+	fmt.Fprintln(out, "  - load <file> [repair [lost+found]]: Replace the folders with the ones in a JSON file, repairing them if asked")
+	fmt.Fprintln(out, "  - save <file>: Write the current folders to a JSON file")
+	fmt.Fprintf(out, "  - generate [roots depth fanout]: Replace the folders with random ones (default %d %d %d)\n",
+		folder.DefaultGenerateOptions.Roots, folder.DefaultGenerateOptions.Depth, folder.DefaultGenerateOptions.MaxChildren)
+	fmt.Fprintln(out, "  - exit|q|quit: Exit the REPL")
+	fmt.Fprintln(out)
+
+	r := &repl{out: out}
+	r.use(folder.GetAllFolders())
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+		tokens := strings.Fields(line)
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		command := tokens[0]
+		switch command {
+		case "list":
+			order := folder.OrderByPath
+			if len(tokens) > 1 {
+				var ok bool
+				if order, ok = listOrders[tokens[1]]; !ok {
+					fmt.Fprintln(out, "Error: Unknown order. Usage: list [path|name|dfs]")
+					continue
+				}
+			}
+			fmt.Fprintln(out, "Listing all folders:")
+			r.prettyPrint(r.folderDriver.GetAllFolders(order))
+
+		case "get":
+			if len(tokens) < 2 {
+				fmt.Fprintln(out, "Error: Missing orgID argument. Usage: get <orgID>")
+				continue
+			}
+			orgIDStr := tokens[1]
+			orgID := uuid.FromStringOrNil(orgIDStr)
+			orgFolders := r.folderDriver.GetFoldersByOrgID(orgID)
+
+			if len(orgFolders) == 0 {
+				fmt.Fprintf(out, "No folders found for orgID: %s\n", orgID)
+			} else {
+				fmt.Fprintf(out, "Folders for orgID: %s\n", orgID)
+				r.prettyPrint(orgFolders)
+			}
+
+		case "children":
+			if len(tokens) < 3 {
+				fmt.Fprintln(out, "Error: Missing argument. Usage: children <orgID,name>")
+				continue
+			}
+			orgID := uuid.FromStringOrNil(tokens[1])
+			nameStr := tokens[2]
+			childFolders := r.folderDriver.GetAllChildFolders(orgID, nameStr)
+			if len(childFolders) == 0 {
+				fmt.Fprintf(out, "No folders found for <orgID,name>: %s,%s\n", orgID, nameStr)
+			} else {
+				fmt.Fprintf(out, "Folders for <orgID,name>: %s,%s\n", orgID, nameStr)
+				r.prettyPrint(childFolders)
+			}
+
+		case "move":
+			if len(tokens) < 3 {
+				fmt.Fprintln(out, "Error: Missing argument. Usage: move <src,dst>")
+				continue
+			}
+			src := tokens[1]
+			dst := tokens[2]
+			resultFolders, err := r.folderDriver.MoveFolder(src, dst)
+			if err != nil {
+				fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+			} else {
+				fmt.Fprintf(out, "Folders for <src,dst>: %s,%s\n", src, dst)
+				r.prettyPrint(resultFolders)
+			}
+
+		case "tree":
+			var err error
+			if len(tokens) < 2 {
+				err = folder.RenderFolders(out, r.folderDriver.GetAllFolders(folder.OrderByPath), folder.TreeOptions{})
+			} else {
+				orgID := uuid.FromStringOrNil(tokens[1])
+				name := ""
+				if len(tokens) > 2 {
+					name = tokens[2]
+				}
+				err = r.folderDriver.RenderTree(out, orgID, name, folder.TreeOptions{})
+			}
+			if err != nil {
+				fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+			}
+
+		case "load":
+			if len(tokens) < 2 {
+				fmt.Fprintln(out, "Error: Missing file argument. Usage: load <file>")
+				continue
+			}
+			folders, err := folder.NewJSONFileStore(tokens[1]).Load()
+			if err != nil {
+				fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+				continue
+			}
+			if len(tokens) < 3 {
+				driver, err := folder.NewDriverWithValidation(folders)
+				if err != nil {
+					fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+					continue
+				}
+				r.folderDriver = driver
+				fmt.Fprintf(out, "Loaded %d folders from %s\n", len(folders), tokens[1])
+				continue
+			}
+
+			if tokens[2] != "repair" {
+				fmt.Fprintln(out, "Error: Unknown load mode. Usage: load <file> [repair [lost+found]]")
+				continue
+			}
+			var opts folder.RepairOptions
+			if len(tokens) > 3 {
+				opts.LostAndFound = tokens[3]
+			}
+			driver, repairs, err := folder.NewDriverWithRepair(folders, opts)
+			if err != nil {
+				fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+				continue
+			}
+			r.folderDriver = driver
+			for _, repair := range repairs {
+				fmt.Fprintln(out, repair)
+			}
+			fmt.Fprintf(out, "Loaded %d folders from %s with %d repairs\n", len(driver.GetAllFolders(folder.OrderByPath)), tokens[1], len(repairs))
+
+		case "save":
+			if len(tokens) < 2 {
+				fmt.Fprintln(out, "Error: Missing file argument. Usage: save <file>")
+				continue
+			}
+			folders := r.folderDriver.GetAllFolders(folder.OrderByPath)
+			if err := folder.NewJSONFileStore(tokens[1]).Save(folders); err != nil {
+				fmt.Fprintf(out, "Error encountered. %s\n", err.Error())
+				continue
+			}
+			fmt.Fprintf(out, "Saved %d folders to %s\n", len(folders), tokens[1])
+
+		case "generate":
+			opts, err := parseGenerateOptions(tokens[1:])
+			if err != nil {
+				fmt.Fprintf(out, "Error: %s. Usage: generate [roots depth fanout]\n", err)
+				continue
+			}
+			folders := folder.GenerateDataWithOptions(opts)
+			r.use(folders)
+			fmt.Fprintf(out, "Generated %d folders\n", len(folders))
+
+		case "q":
+			fmt.Fprintln(out, "Exiting...")
+			return
+		case "quit":
+			fmt.Fprintln(out, "Exiting...")
+			return
+		case "exit":
+			fmt.Fprintln(out, "Exiting...")
+			return
+
+		default:
+			fmt.Fprintf(out, "Unknown command: %s\n", command)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(out, "Error reading input: %v\n", err)
+	}
+}
+
+// swaps in a driver built from a new dataset
+func (r *repl) use(folders []folder.Folder) {
+	r.folderDriver = folder.NewDriver(folders)
+}
+
+func (r *repl) prettyPrint(b interface{}) {
+	fmt.Fprint(r.out, string(folder.MarshalJson(b)))
+}
+
+// reads the optional positional roots, depth and fanout arguments, missing
+// ones keep their defaults
+func parseGenerateOptions(args []string) (folder.GenerateOptions, error) {
+	opts := folder.DefaultGenerateOptions
+	if len(args) > 3 {
+		return opts, fmt.Errorf("Too many arguments")
+	}
+
+	fields := []*int{&opts.Roots, &opts.Depth, &opts.MaxChildren}
+	names := []string{"roots", "depth", "fanout"}
+	for i, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return opts, fmt.Errorf("%s must be a positive integer, got %q", names[i], arg)
+		}
+		*fields[i] = n
+	}
+	return opts, nil
+}
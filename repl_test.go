@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/go-test/deep"
+	"github.com/gofrs/uuid"
+)
+
+// runs the REPL over script and returns what it printed
+func runTestREPL(script ...string) string {
+	var out bytes.Buffer
+	runREPL(strings.NewReader(strings.Join(script, "\n")+"\n"), &out)
+	return out.String()
+}
+
+func Test_runREPL_LoadMoveSave(t *testing.T) {
+	t.Parallel()
+	in := writeTestData(t, testCLIFolders())
+	out := filepath.Join(t.TempDir(), "saved.json")
+
+	output := runTestREPL("load "+in, "move bravo delta", "save "+out, "quit")
+	if !strings.Contains(output, "Loaded 5 folders") {
+		t.Errorf("load was not reported, got:\n%s", output)
+	}
+
+	saved, err := folder.NewJSONFileStore(out).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range folder.SortFoldersByPath(saved) {
+		paths = append(paths, f.Paths)
+	}
+	want := []string{"alpha", "delta", "delta.bravo", "delta.bravo.charlie", "echo"}
+	if diff := deep.Equal(paths, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func Test_runREPL_Errors(t *testing.T) {
+	t.Parallel()
+	tests := [...]struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"load without file", "load", "Usage: load <file>"},
+		{"load missing file", "load " + filepath.Join(t.TempDir(), "missing.json"), "Error encountered."},
+		{"save without file", "save", "Usage: save <file>"},
+		{"save to missing directory", "save " + filepath.Join(t.TempDir(), "missing", "out.json"), "Error encountered."},
+		{"generate with bad count", "generate 0", "roots must be a positive integer"},
+		{"generate with too many arguments", "generate 1 2 3 4", "Too many arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			output := runTestREPL(tt.command)
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("want output containing %q, got:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func Test_runREPL_Generate(t *testing.T) {
+	t.Parallel()
+	out := filepath.Join(t.TempDir(), "generated.json")
+
+	// a fanout of one gives every folder exactly one child
+	output := runTestREPL("generate 2 3 1", "save "+out)
+	if !strings.Contains(output, "Generated 6 folders") {
+		t.Errorf("generate was not reported, got:\n%s", output)
+	}
+
+	saved, err := folder.NewJSONFileStore(out).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 6 {
+		t.Errorf("saved %d folders, want 6", len(saved))
+	}
+	for _, f := range saved {
+		if depth := strings.Count(f.Paths, ".") + 1; depth > 3 {
+			t.Errorf("%s is %d levels deep, want at most 3", f.Paths, depth)
+		}
+	}
+}
+
+func Test_runREPL_ListAfterMove(t *testing.T) {
+	t.Parallel()
+	in := writeTestData(t, testCLIFolders())
+
+	output := runTestREPL("load "+in, "move bravo delta", "list")
+	listing := output[strings.LastIndex(output, "Listing all folders:"):]
+
+	// by path, so moved folders follow their new parent
+	want := []string{`"alpha"`, `"delta"`, `"delta.bravo"`, `"delta.bravo.charlie"`, `"echo"`}
+	last := -1
+	for _, path := range want {
+		i := strings.Index(listing, `"paths": `+path)
+		if i < 0 || i < last {
+			t.Fatalf("%s is out of order in:\n%s", path, listing)
+		}
+		last = i
+	}
+}
+
+func Test_runREPL_ListUnknownOrder(t *testing.T) {
+	t.Parallel()
+
+	output := runTestREPL("list sideways")
+	if !strings.Contains(output, "Usage: list [path|name|dfs]") {
+		t.Errorf("unknown order was not reported, got:\n%s", output)
+	}
+}
+
+func Test_runREPL_Tree(t *testing.T) {
+	t.Parallel()
+	in := writeTestData(t, testCLIFolders())
+
+	tests := [...]struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"organization", "tree " + FirstOrgID, "> alpha\n└── bravo\n    └── charlie\ndelta\n> "},
+		{"subtree", "tree " + FirstOrgID + " bravo", "> bravo\n└── charlie\n> "},
+		{"missing folder", "tree " + FirstOrgID + " zulu", "> Error encountered. " + folder.ErrFolderNotFound.Error() + "\n> "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			output := runTestREPL("load "+in, tt.command)
+			if !strings.HasSuffix(output, tt.want) {
+				t.Errorf("want output ending in:\n%s\ngot:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func Test_runREPL_LoadInvalid(t *testing.T) {
+	t.Parallel()
+	in := writeTestData(t, []folder.Folder{
+		{Name: "bravo", OrgId: uuid.FromStringOrNil(FirstOrgID), Paths: "alpha.bravo"},
+	})
+
+	output := runTestREPL("load " + in)
+	if !strings.Contains(output, string(folder.ProblemMissingParent)) {
+		t.Errorf("validation problems were not reported, got:\n%s", output)
+	}
+	if strings.Contains(output, "Loaded") {
+		t.Errorf("invalid folders were loaded, got:\n%s", output)
+	}
+}
+
+func Test_runREPL_LoadRepair(t *testing.T) {
+	t.Parallel()
+	in := writeTestData(t, []folder.Folder{
+		{Name: "alpha", OrgId: uuid.FromStringOrNil(FirstOrgID), Paths: "alpha"},
+		{Name: "charlie", OrgId: uuid.FromStringOrNil(FirstOrgID), Paths: "missing.charlie"},
+	})
+
+	output := runTestREPL("load "+in+" repair lost+found", "tree "+FirstOrgID)
+	if !strings.Contains(output, string(folder.RepairReparented)) {
+		t.Errorf("repairs were not reported, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Loaded 3 folders") {
+		t.Errorf("repaired folders were not loaded, got:\n%s", output)
+	}
+	if !strings.Contains(output, "lost+found\n└── charlie\n") {
+		t.Errorf("orphan was not moved under lost+found, got:\n%s", output)
+	}
+}